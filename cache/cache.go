@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"sitemap-checker/logger"
+)
+
+// Cache зберігає байтові значення за ключем з обмеженим часом життя
+type Cache interface {
+	// Get повертає значення та true, якщо ключ знайдено і він не прострочений
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	// Set зберігає значення; ttl <= 0 означає безстрокове зберігання
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	// Delete видаляє ключ
+	Delete(ctx context.Context, key string) error
+	// Close звільняє ресурси кешу
+	Close() error
+}
+
+// Options визначає тип кешу та його параметри
+type Options struct {
+	Backend    string // memory, disk, redis або auto (Redis, якщо доступний, інакше диск)
+	Dir        string // Каталог для disk; порожній — DefaultDir()
+	MaxEntries int    // Максимальна кількість записів для memory
+	Redis      RedisOptions
+	// RedisRequired забороняє тихий перехід на диск у режимі auto,
+	// якщо Redis недоступний, та кеш memory або disk
+	RedisRequired bool
+}
+
+// New створює кеш за вказаними параметрами
+func New(opts Options) (Cache, error) {
+	if opts.RedisRequired && (opts.Backend == "memory" || opts.Backend == "disk") {
+		return nil, fmt.Errorf("REDIS_REQUIRED вимагає Redis, а вибрано кеш %s: вкажіть CACHE_BACKEND=redis або auto", opts.Backend)
+	}
+
+	switch opts.Backend {
+	case "memory":
+		return NewMemory(opts.MaxEntries), nil
+	case "disk":
+		return NewDisk(diskDir(opts.Dir))
+	case "redis":
+		client, err := ConnectRedis(opts.Redis)
+		if err != nil {
+			return nil, err
+		}
+		return NewRedis(client), nil
+	case "", "auto":
+		client, err := ConnectRedis(opts.Redis)
+		if err != nil {
+			if opts.RedisRequired {
+				return nil, err
+			}
+			logger.Info("Redis недоступний, використовується кеш на диску: %v", err)
+			return NewDisk(diskDir(opts.Dir))
+		}
+		return NewRedis(client), nil
+	default:
+		return nil, fmt.Errorf("невідомий тип кешу: %s", opts.Backend)
+	}
+}
+
+// DefaultDir повертає постійний каталог кешу користувача
+// (наприклад, ~/.cache/sitemap-checker у Linux)
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sitemap-checker"), nil
+}
+
+// diskDir повертає каталог для кешу на диску: вказаний або DefaultDir(); якщо
+// постійний каталог визначити не вдалося, кеш буде тимчасовим і не збережеться між запусками
+func diskDir(dir string) string {
+	if dir != "" {
+		return dir
+	}
+	dir, err := DefaultDir()
+	if err != nil {
+		logger.Error("УВАГА: не вдалося визначити каталог кешу користувача (%v), кеш на диску тимчасовий і буде видалений після завершення; вкажіть CACHE_DIR", err)
+		return ""
+	}
+	return dir
+}
+
+// namespaced додає префікс простору імен до всіх ключів
+type namespaced struct {
+	cache  Cache
+	prefix string
+}
+
+// Namespace повертає кеш, у якому ключі відокремлені префіксом namespace.
+// Close такого кешу не закриває базовий кеш
+func Namespace(c Cache, namespace string) Cache {
+	return &namespaced{cache: c, prefix: namespace + ":"}
+}
+
+// Get повертає значення з простору імен
+func (n *namespaced) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return n.cache.Get(ctx, n.prefix+key)
+}
+
+// Set зберігає значення в просторі імен
+func (n *namespaced) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return n.cache.Set(ctx, n.prefix+key, value, ttl)
+}
+
+// Delete видаляє ключ з простору імен
+func (n *namespaced) Delete(ctx context.Context, key string) error {
+	return n.cache.Delete(ctx, n.prefix+key)
+}
+
+// Close нічого не робить: базовий кеш закривається власником
+func (n *namespaced) Close() error {
+	return nil
+}
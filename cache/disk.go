@@ -0,0 +1,122 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Disk — кеш у файлах приватного каталогу.
+// Кожен файл містить у першому рядку час закінчення дії (Unix, наносекунди; 0 — безстроково)
+type Disk struct {
+	dir       string
+	temporary bool // Каталог створено автоматично і буде видалено при Close
+}
+
+// NewDisk створює кеш у каталозі dir; якщо dir порожній, створюється
+// приватний тимчасовий каталог, який видаляється при Close
+func NewDisk(dir string) (*Disk, error) {
+	if dir == "" {
+		tempDir, err := os.MkdirTemp("", "sitemap-checker-cache-")
+		if err != nil {
+			return nil, fmt.Errorf("помилка при створенні каталогу кешу: %v", err)
+		}
+		return &Disk{dir: tempDir, temporary: true}, nil
+	}
+
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("помилка при створенні каталогу кешу: %v", err)
+	}
+	return &Disk{dir: dir}, nil
+}
+
+// Get читає значення з файлу та видаляє прострочені записи
+func (d *Disk) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	name := d.path(key)
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("помилка при читанні кешу: %v", err)
+	}
+
+	header, value, ok := bytes.Cut(data, []byte("\n"))
+	if !ok {
+		return nil, false, fmt.Errorf("пошкоджений запис кешу: %s", name)
+	}
+	expiresAt, err := strconv.ParseInt(string(header), 10, 64)
+	if err != nil {
+		return nil, false, fmt.Errorf("пошкоджений запис кешу: %s", name)
+	}
+
+	if expiresAt != 0 && time.Now().UnixNano() > expiresAt {
+		_ = os.Remove(name)
+		return nil, false, nil
+	}
+
+	return value, true, nil
+}
+
+// Set атомарно записує значення у файл
+func (d *Disk) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl).UnixNano()
+	}
+
+	tmp, err := os.CreateTemp(d.dir, ".tmp-")
+	if err != nil {
+		return fmt.Errorf("помилка при записі кешу: %v", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	w := bufio.NewWriter(tmp)
+	_, _ = w.WriteString(strconv.FormatInt(expiresAt, 10) + "\n")
+	_, _ = w.Write(value)
+	if err := w.Flush(); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("помилка при записі кешу: %v", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("помилка при записі кешу: %v", err)
+	}
+
+	if err := os.Rename(tmp.Name(), d.path(key)); err != nil {
+		return fmt.Errorf("помилка при записі кешу: %v", err)
+	}
+	return nil
+}
+
+// Delete видаляє файл запису
+func (d *Disk) Delete(ctx context.Context, key string) error {
+	err := os.Remove(d.path(key))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("помилка при видаленні запису кешу: %v", err)
+	}
+	return nil
+}
+
+// Close видаляє тимчасовий каталог кешу, якщо його було створено автоматично
+func (d *Disk) Close() error {
+	if !d.temporary {
+		return nil
+	}
+	if err := os.RemoveAll(d.dir); err != nil {
+		return fmt.Errorf("помилка при видаленні каталогу кешу: %v", err)
+	}
+	return nil
+}
+
+// path повертає шлях до файлу запису
+func (d *Disk) path(key string) string {
+	return filepath.Join(d.dir, fmt.Sprintf("%x", sha256.Sum256([]byte(key))))
+}
@@ -0,0 +1,156 @@
+package cache
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Redis — кеш у Redis
+type Redis struct {
+	client redis.UniversalClient
+}
+
+// RedisOptions визначає параметри підключення до Redis
+type RedisOptions struct {
+	URL              string   // host:port або redis://[user:password@]host:port/db, rediss:// для TLS
+	Mode             string   // standalone, sentinel або cluster
+	Addrs            []string // Адреси вузлів Sentinel або Cluster; порожньо — хост з URL
+	MasterName       string   // Ім'я master для Sentinel
+	SentinelPassword string   // Пароль Sentinel (якщо відрізняється від пароля Redis)
+	TLSCAFile        string   // Файл CA для перевірки сертифіката сервера
+	TLSInsecure      bool     // Вимкнути перевірку сертифіката сервера
+}
+
+// ConnectRedis підключається до Redis і перевіряє з'єднання
+func ConnectRedis(opts RedisOptions) (redis.UniversalClient, error) {
+	base, err := parseRedisURL(opts.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.TLSCAFile != "" || opts.TLSInsecure {
+		if base.TLSConfig == nil {
+			base.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		base.TLSConfig.InsecureSkipVerify = opts.TLSInsecure
+		if opts.TLSCAFile != "" {
+			pem, err := os.ReadFile(opts.TLSCAFile)
+			if err != nil {
+				return nil, fmt.Errorf("помилка при читанні CA для Redis: %v", err)
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("не знайдено сертифікатів у %s", opts.TLSCAFile)
+			}
+			base.TLSConfig.RootCAs = pool
+		}
+	}
+
+	addrs := opts.Addrs
+	if len(addrs) == 0 {
+		addrs = []string{base.Addr}
+	}
+
+	var client redis.UniversalClient
+	switch opts.Mode {
+	case "", "standalone":
+		client = redis.NewClient(base)
+	case "sentinel":
+		if opts.MasterName == "" {
+			return nil, fmt.Errorf("для режиму sentinel потрібно вказати ім'я master")
+		}
+		client = redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       opts.MasterName,
+			SentinelAddrs:    addrs,
+			SentinelPassword: opts.SentinelPassword,
+			Username:         base.Username,
+			Password:         base.Password,
+			DB:               base.DB,
+			TLSConfig:        base.TLSConfig,
+		})
+	case "cluster":
+		if base.DB != 0 {
+			return nil, fmt.Errorf("Redis Cluster підтримує лише базу даних 0")
+		}
+		client = redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:     addrs,
+			Username:  base.Username,
+			Password:  base.Password,
+			TLSConfig: base.TLSConfig,
+		})
+	default:
+		return nil, fmt.Errorf("невідомий режим Redis: %s", opts.Mode)
+	}
+
+	// Перевіряємо підключення до Redis
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("помилка при підключенні до Redis: %v", err)
+	}
+
+	return client, nil
+}
+
+// parseRedisURL розбирає redis:// або rediss:// URL; рядок без схеми вважається адресою host:port
+func parseRedisURL(rawURL string) (*redis.Options, error) {
+	if !strings.Contains(rawURL, "://") {
+		return &redis.Options{Addr: rawURL}, nil
+	}
+
+	opts, err := redis.ParseURL(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("невірний формат URL Redis: %v", err)
+	}
+	return opts, nil
+}
+
+// NewRedis створює кеш поверх клієнта Redis
+func NewRedis(client redis.UniversalClient) *Redis {
+	return &Redis{client: client}
+}
+
+// Get повертає значення з Redis
+func (r *Redis) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	value, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("помилка при читанні з Redis: %v", err)
+	}
+	return value, true, nil
+}
+
+// Set зберігає значення в Redis
+func (r *Redis) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	if ttl < 0 {
+		ttl = 0
+	}
+	if err := r.client.Set(ctx, key, value, ttl).Err(); err != nil {
+		return fmt.Errorf("помилка при записі в Redis: %v", err)
+	}
+	return nil
+}
+
+// Delete видаляє ключ з Redis
+func (r *Redis) Delete(ctx context.Context, key string) error {
+	if err := r.client.Del(ctx, key).Err(); err != nil {
+		return fmt.Errorf("помилка при видаленні з Redis: %v", err)
+	}
+	return nil
+}
+
+// Close закриває з'єднання з Redis
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+
+	"sitemap-checker/logger"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "cache-test-")
+	if err != nil {
+		panic(err)
+	}
+	logger.Init(filepath.Join(dir, "errors.log"))
+	code := m.Run()
+	logger.Close()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// newTestRedis запускає miniredis і підключає до нього кеш
+func newTestRedis(t *testing.T) (*miniredis.Miniredis, Cache) {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	c, err := New(Options{Backend: "redis", Redis: RedisOptions{URL: "redis://" + mr.Addr() + "/0"}})
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	return mr, c
+}
+
+func TestRedisGetSet(t *testing.T) {
+	ctx := context.Background()
+	_, c := newTestRedis(t)
+
+	if _, ok, err := c.Get(ctx, "missing"); ok || err != nil {
+		t.Fatalf("Get відсутнього ключа = %v, %v; очікувався промах без помилки", ok, err)
+	}
+
+	if err := c.Set(ctx, "key", []byte("value"), 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	value, ok, err := c.Get(ctx, "key")
+	if err != nil || !ok || string(value) != "value" {
+		t.Fatalf("Get = %q, %v, %v; очікувалось \"value\"", value, ok, err)
+	}
+
+	if err := c.Delete(ctx, "key"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, ok, _ := c.Get(ctx, "key"); ok {
+		t.Errorf("ключ знайдено після Delete")
+	}
+}
+
+func TestRedisTTL(t *testing.T) {
+	ctx := context.Background()
+	mr, c := newTestRedis(t)
+
+	if err := c.Set(ctx, "short", []byte("1"), time.Minute); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := c.Set(ctx, "forever", []byte("2"), 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := c.Set(ctx, "negative", []byte("3"), -time.Second); err != nil {
+		t.Fatalf("Set з від'ємним ttl: %v", err)
+	}
+
+	if ttl := mr.TTL("short"); ttl != time.Minute {
+		t.Errorf("TTL short = %v, очікувалось 1m", ttl)
+	}
+	if ttl := mr.TTL("forever"); ttl != 0 {
+		t.Errorf("TTL forever = %v, очікувалось безстрокове зберігання", ttl)
+	}
+	if ttl := mr.TTL("negative"); ttl != 0 {
+		t.Errorf("TTL negative = %v, очікувалось безстрокове зберігання", ttl)
+	}
+
+	mr.FastForward(2 * time.Minute)
+	if _, ok, err := c.Get(ctx, "short"); ok || err != nil {
+		t.Errorf("прострочений ключ: ok = %v, err = %v; очікувався промах", ok, err)
+	}
+	if _, ok, _ := c.Get(ctx, "forever"); !ok {
+		t.Errorf("безстроковий ключ зник")
+	}
+}
+
+func TestRedisNamespace(t *testing.T) {
+	ctx := context.Background()
+	mr, c := newTestRedis(t)
+
+	robots := Namespace(c, "sitemap-checker:robots")
+	snapshots := Namespace(c, "sitemap-checker:snapshot")
+	if err := robots.Set(ctx, "https://example.com/robots.txt", []byte("robots"), time.Hour); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := snapshots.Set(ctx, "https://example.com/robots.txt", []byte("snapshot"), 0); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	want := []string{
+		"sitemap-checker:robots:https://example.com/robots.txt",
+		"sitemap-checker:snapshot:https://example.com/robots.txt",
+	}
+	if keys := mr.Keys(); !reflect.DeepEqual(keys, want) {
+		t.Errorf("ключі в Redis = %v, очікувалось %v", keys, want)
+	}
+	if value, ok, _ := robots.Get(ctx, "https://example.com/robots.txt"); !ok || string(value) != "robots" {
+		t.Errorf("robots.Get = %q, %v; очікувалось \"robots\"", value, ok)
+	}
+	if _, ok, _ := c.Get(ctx, "https://example.com/robots.txt"); ok {
+		t.Errorf("ключ без префікса знайдено в базовому кеші")
+	}
+
+	// Close простору імен не закриває базовий кеш
+	if err := robots.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	if _, ok, err := snapshots.Get(ctx, "https://example.com/robots.txt"); !ok || err != nil {
+		t.Errorf("кеш недоступний після Close простору імен: %v, %v", ok, err)
+	}
+}
+
+func TestRedisAutoFallback(t *testing.T) {
+	mr := miniredis.RunT(t)
+	addr := mr.Addr()
+	mr.Close()
+
+	opts := Options{Backend: "auto", Dir: t.TempDir(), Redis: RedisOptions{URL: addr}}
+	c, err := New(opts)
+	if err != nil {
+		t.Fatalf("New(auto) з недоступним Redis: %v", err)
+	}
+	defer c.Close()
+	if _, ok := c.(*Disk); !ok {
+		t.Errorf("New(auto) = %T, очікувався кеш на диску", c)
+	}
+
+	opts.RedisRequired = true
+	if c, err := New(opts); err == nil {
+		c.Close()
+		t.Errorf("New(auto) з REDIS_REQUIRED не повернув помилку")
+	}
+}
+
+func TestRedisRequiredBackend(t *testing.T) {
+	tests := []struct {
+		backend string
+		wantErr bool
+	}{
+		{"memory", true},
+		{"disk", true},
+		{"redis", false},
+		{"auto", false},
+	}
+
+	mr := miniredis.RunT(t)
+	for _, tt := range tests {
+		c, err := New(Options{Backend: tt.backend, Dir: t.TempDir(), Redis: RedisOptions{URL: mr.Addr()}, RedisRequired: true})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("New(%s) з REDIS_REQUIRED: помилка = %v, очікувалась помилка: %v", tt.backend, err, tt.wantErr)
+		}
+		if err == nil {
+			c.Close()
+		}
+	}
+}
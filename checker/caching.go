@@ -0,0 +1,272 @@
+package checker
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Типи знахідок щодо заголовків кешування
+const (
+	FindingCacheUncacheable = "cache_uncacheable"
+	FindingCacheVary        = "cache_vary"
+)
+
+// Стан кешу CDN для відповіді
+const (
+	CacheHit  = "hit"
+	CacheMiss = "miss"
+)
+
+// cdnCacheHeaders — заголовки, якими CDN та проксі повідомляють про влучання в кеш,
+// у порядку пріоритету
+var cdnCacheHeaders = []string{"Cache-Status", "CF-Cache-Status", "X-Cache", "X-Cache-Status"}
+
+// Caching — розібрані заголовки кешування відповіді
+type Caching struct {
+	CacheControl map[string]string `json:"cache_control,omitempty"` // Директиви Cache-Control; значення порожнє для директив без аргументу
+	Expires      string            `json:"expires,omitempty"`
+	Age          *int              `json:"age,omitempty"`
+	Vary         []string          `json:"vary,omitempty"`
+	ETag         string            `json:"etag,omitempty"`
+	LastModified string            `json:"last_modified,omitempty"`
+	CDNHeader    string            `json:"cdn_header,omitempty"` // Заголовок, з якого взято стан кешу CDN
+	CDNStatus    string            `json:"cdn_status,omitempty"` // Значення заголовка як є
+	CacheStatus  string            `json:"cache_status,omitempty"`
+	Cacheable    bool              `json:"cacheable"`
+}
+
+// CacheHostSummary — підсумок кешування сторінок одного хоста
+type CacheHostSummary struct {
+	Host        string  `json:"host"`
+	Pages       int     `json:"pages"`
+	Uncacheable int     `json:"uncacheable"` // HTML-сторінки, що не кешуються
+	Hits        int     `json:"hits"`
+	Misses      int     `json:"misses"`
+	HitRatio    float64 `json:"hit_ratio"`     // Частка влучань серед відповідей зі станом кешу CDN
+	LowHitRatio bool    `json:"low_hit_ratio"` // Частка влучань нижча за CACHE_HIT_RATIO_THRESHOLD
+}
+
+// checkCaching розбирає заголовки кешування відповіді та перевіряє кешованість HTML
+func (c *Checker) checkCaching(ctx context.Context, page *Page, result *PageResult) {
+	if page.Response.StatusCode != http.StatusOK {
+		return
+	}
+	header := page.Response.Header
+
+	caching := &Caching{
+		CacheControl: parseCacheControl(header.Values("Cache-Control")),
+		Expires:      header.Get("Expires"),
+		ETag:         header.Get("ETag"),
+		LastModified: header.Get("Last-Modified"),
+	}
+	if age, err := strconv.Atoi(strings.TrimSpace(header.Get("Age"))); err == nil && age >= 0 {
+		caching.Age = &age
+	}
+	for _, value := range header.Values("Vary") {
+		for _, field := range strings.Split(value, ",") {
+			if field = strings.TrimSpace(field); field != "" {
+				caching.Vary = append(caching.Vary, field)
+			}
+		}
+	}
+	for _, name := range cdnCacheHeaders {
+		if value := header.Get(name); value != "" {
+			caching.CDNHeader = name
+			caching.CDNStatus = value
+			caching.CacheStatus = cdnCacheStatus(name, value)
+			break
+		}
+	}
+
+	reason := uncacheableReason(caching, page.Response.Header.Get("Date"))
+	caching.Cacheable = reason == ""
+	result.Caching = caching
+
+	if !caching.Cacheable && isHTML(result.ContentType) {
+		result.addFinding(FindingCacheUncacheable, SeverityWarning, "", "HTML-сторінка не кешується: %s", reason)
+	}
+	for _, field := range caching.Vary {
+		switch strings.ToLower(field) {
+		case "*":
+			result.addFinding(FindingCacheVary, SeverityWarning, "", "Vary: * робить відповідь некешованою для спільних кешів")
+		case "user-agent":
+			result.addFinding(FindingCacheVary, SeverityWarning, "", "Vary: User-Agent розбиває кеш на окремі копії для кожного User-Agent")
+		}
+	}
+}
+
+// parseCacheControl розбирає директиви Cache-Control у мапу з ключами в нижньому регістрі
+func parseCacheControl(values []string) map[string]string {
+	directives := make(map[string]string)
+	for _, value := range values {
+		for _, directive := range splitQuoted(value, ',') {
+			name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				directives[name] = strings.Trim(strings.TrimSpace(arg), `"`)
+			}
+		}
+	}
+	if len(directives) == 0 {
+		return nil
+	}
+	return directives
+}
+
+// splitQuoted ділить значення заголовка за роздільником поза рядками в лапках,
+// як у no-cache="Set-Cookie, X-Token"
+func splitQuoted(value string, sep byte) []string {
+	var parts []string
+	start, quoted := 0, false
+	for i := 0; i < len(value); i++ {
+		switch value[i] {
+		case '\\':
+			if quoted {
+				i++
+			}
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				parts = append(parts, value[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, value[start:])
+}
+
+// uncacheableReason повертає причину, з якої відповідь не кешується; порожній
+// рядок — відповідь можна кешувати або повторно перевіряти за валідатором
+func uncacheableReason(caching *Caching, date string) string {
+	directives := caching.CacheControl
+	if _, ok := directives["no-store"]; ok {
+		return "Cache-Control: no-store"
+	}
+	// private і no-cache зі списком полів стосуються лише цих полів, а не всієї відповіді
+	if value, ok := directives["private"]; ok && value == "" {
+		return "Cache-Control: private"
+	}
+	for _, field := range caching.Vary {
+		if field == "*" {
+			return "Vary: *"
+		}
+	}
+
+	// Відповідь з валідатором можна повторно використати після умовного запиту
+	if caching.ETag != "" || caching.LastModified != "" {
+		return ""
+	}
+	// no-cache вимагає перевірки перед кожним використанням, тож без валідатора
+	// строк свіжості не допомагає
+	if value, ok := directives["no-cache"]; ok && value == "" {
+		return "Cache-Control: no-cache без ETag чи Last-Modified"
+	}
+	for _, name := range []string{"s-maxage", "max-age"} {
+		if value, ok := directives[name]; ok {
+			if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+				return ""
+			}
+			return "Cache-Control: " + name + "=" + value + " без ETag чи Last-Modified"
+		}
+	}
+	if caching.Expires != "" {
+		expires, err := http.ParseTime(caching.Expires)
+		now := time.Now()
+		if served, err := http.ParseTime(date); err == nil {
+			now = served
+		}
+		if err == nil && expires.After(now) {
+			return ""
+		}
+		return "Expires у минулому або некоректний"
+	}
+	return "немає Cache-Control, Expires чи валідаторів"
+}
+
+// cdnCacheStatus зводить значення заголовка стану кешу до hit або miss;
+// порожній рядок — стан невідомий
+func cdnCacheStatus(name, value string) string {
+	value = strings.ToLower(value)
+	switch name {
+	case "Cache-Status":
+		// RFC 9211: "ExampleCache; hit" або "ExampleCache; fwd=miss"; важить останній
+		// кеш у ланцюжку, найближчий до клієнта
+		entries := splitQuoted(value, ',')
+		params := splitQuoted(entries[len(entries)-1], ';')
+		for _, param := range params[1:] {
+			name, _, _ := strings.Cut(strings.TrimSpace(param), "=")
+			switch strings.TrimSpace(name) {
+			case "hit":
+				return CacheHit
+			case "fwd":
+				return CacheMiss
+			}
+		}
+		return ""
+	case "X-Cache":
+		// Fastly перелічує стан кожного вузла; важить останній, найближчий до клієнта
+		entries := strings.Split(value, ",")
+		value = strings.TrimSpace(entries[len(entries)-1])
+	}
+
+	switch {
+	case strings.Contains(value, "hit"), strings.Contains(value, "stale"),
+		strings.Contains(value, "revalidated"), strings.Contains(value, "updating"):
+		return CacheHit
+	case strings.Contains(value, "miss"), strings.Contains(value, "expired"),
+		strings.Contains(value, "bypass"), strings.Contains(value, "dynamic"):
+		return CacheMiss
+	}
+	return ""
+}
+
+// reportCaching підсумовує кешованість та частку влучань у кеш CDN за хостами
+func (c *Checker) reportCaching(report *Report) {
+	report.Caching = make([]CacheHostSummary, 0)
+
+	hosts := make(map[string]*CacheHostSummary)
+	var order []string
+	for _, page := range report.Pages {
+		if page.Caching == nil {
+			continue
+		}
+		u, err := url.Parse(page.URL)
+		if err != nil {
+			continue
+		}
+
+		summary, ok := hosts[u.Host]
+		if !ok {
+			summary = &CacheHostSummary{Host: u.Host}
+			hosts[u.Host] = summary
+			order = append(order, u.Host)
+		}
+
+		summary.Pages++
+		if !page.Caching.Cacheable && isHTML(page.ContentType) {
+			summary.Uncacheable++
+		}
+		switch page.Caching.CacheStatus {
+		case CacheHit:
+			summary.Hits++
+		case CacheMiss:
+			summary.Misses++
+		}
+	}
+
+	sort.Strings(order)
+	for _, host := range order {
+		summary := hosts[host]
+		if total := summary.Hits + summary.Misses; total > 0 {
+			summary.HitRatio = math.Round(float64(summary.Hits)/float64(total)*1000) / 1000
+			summary.LowHitRatio = summary.HitRatio < c.cfg.CacheHitRatioThreshold
+		}
+		report.Caching = append(report.Caching, *summary)
+	}
+}
@@ -0,0 +1,96 @@
+package checker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseCacheControl(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   map[string]string
+	}{
+		{nil, nil},
+		{[]string{" , "}, nil},
+		{[]string{"Public, MAX-AGE=600", "s-maxage=\"3600\""}, map[string]string{"public": "", "max-age": "600", "s-maxage": "3600"}},
+		{[]string{`no-cache="Set-Cookie, X-Token", max-age=60`}, map[string]string{"no-cache": "Set-Cookie, X-Token", "max-age": "60"}},
+	}
+	for _, tt := range tests {
+		if got := parseCacheControl(tt.values); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseCacheControl(%q) = %v, очікувалось %v", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestUncacheableReason(t *testing.T) {
+	const date = "Sun, 18 Oct 2026 12:00:00 GMT"
+
+	tests := []struct {
+		name    string
+		caching Caching
+		date    string
+		want    string // Фрагмент причини; порожній — відповідь кешується
+	}{
+		{"без заголовків", Caching{}, date, "немає Cache-Control"},
+		{"no-store", Caching{CacheControl: map[string]string{"no-store": "", "max-age": "600"}, ETag: `"v1"`}, date, "no-store"},
+		{"private", Caching{CacheControl: map[string]string{"private": "", "max-age": "600"}}, date, "private"},
+		{"private зі списком полів", Caching{CacheControl: map[string]string{"private": "Set-Cookie", "max-age": "600"}}, date, ""},
+		{"Vary: *", Caching{CacheControl: map[string]string{"max-age": "600"}, Vary: []string{"Accept-Encoding", "*"}}, date, "Vary: *"},
+		{"max-age", Caching{CacheControl: map[string]string{"max-age": "600"}}, date, ""},
+		{"max-age=0", Caching{CacheControl: map[string]string{"max-age": "0"}}, date, "max-age=0"},
+		{"s-maxage важливіший за max-age", Caching{CacheControl: map[string]string{"s-maxage": "0", "max-age": "600"}}, date, "s-maxage=0"},
+		{"no-cache з max-age без валідатора", Caching{CacheControl: map[string]string{"no-cache": "", "max-age": "600"}}, date, "no-cache"},
+		{"no-cache з валідатором", Caching{CacheControl: map[string]string{"no-cache": ""}, ETag: `"v1"`}, date, ""},
+		{"no-cache зі списком полів", Caching{CacheControl: map[string]string{"no-cache": "Set-Cookie", "max-age": "600"}}, date, ""},
+		{"max-age=0 з Last-Modified", Caching{CacheControl: map[string]string{"max-age": "0"}, LastModified: date}, date, ""},
+		{"Expires після Date", Caching{Expires: "Sun, 18 Oct 2026 13:00:00 GMT"}, date, ""},
+		{"Expires до Date", Caching{Expires: "Sun, 18 Oct 2026 11:00:00 GMT"}, date, "Expires"},
+		{"Expires відносно Date, а не поточного часу", Caching{Expires: "Thu, 01 Jan 2015 01:00:00 GMT"}, "Thu, 01 Jan 2015 00:00:00 GMT", ""},
+		{"некоректний Expires", Caching{Expires: "0"}, date, "Expires"},
+		{"max-age важливіший за Expires", Caching{CacheControl: map[string]string{"max-age": "0"}, Expires: "Sun, 18 Oct 2026 13:00:00 GMT"}, date, "max-age=0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := uncacheableReason(&tt.caching, tt.date)
+			if (got == "") != (tt.want == "") || !strings.Contains(got, tt.want) {
+				t.Errorf("uncacheableReason = %q, очікувалось %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCDNCacheStatus(t *testing.T) {
+	tests := []struct {
+		name, value string
+		want        string
+	}{
+		{"Cache-Status", "ExampleCache; hit", CacheHit},
+		{"Cache-Status", "ExampleCache;hit;ttl=30", CacheHit},
+		{"Cache-Status", "ExampleCache; fwd=uri-miss", CacheMiss},
+		{"Cache-Status", "ExampleCache; fwd=stale; fwd-status=304", CacheMiss},
+		// Важить останній кеш, найближчий до клієнта
+		{"Cache-Status", "OriginCache; hit, CDN; fwd=miss", CacheMiss},
+		{"Cache-Status", "OriginCache; fwd=miss, CDN; hit", CacheHit},
+		{"Cache-Status", `CDN; detail="hit, ok"; fwd=miss`, CacheMiss},
+		{"Cache-Status", "CDN; hitcount=3", ""},
+		{"Cache-Status", "CDN", ""},
+		{"CF-Cache-Status", "HIT", CacheHit},
+		{"CF-Cache-Status", "REVALIDATED", CacheHit},
+		{"CF-Cache-Status", "DYNAMIC", CacheMiss},
+		{"CF-Cache-Status", "BYPASS", CacheMiss},
+		{"X-Cache", "Hit from cloudfront", CacheHit},
+		{"X-Cache", "Miss from cloudfront", CacheMiss},
+		{"X-Cache", "MISS, HIT", CacheHit},
+		{"X-Cache", "HIT, MISS", CacheMiss},
+		{"X-Cache", "Error from cloudfront", ""},
+		{"X-Cache-Status", "EXPIRED", CacheMiss},
+		{"X-Cache-Status", "STALE", CacheHit},
+	}
+	for _, tt := range tests {
+		if got := cdnCacheStatus(tt.name, tt.value); got != tt.want {
+			t.Errorf("cdnCacheStatus(%s: %q) = %q, очікувалось %q", tt.name, tt.value, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"sitemap-checker/fetcher"
+	"sitemap-checker/htmldoc"
+	"sitemap-checker/logger"
+)
+
+// Типи знахідок щодо канонічних посилань
+const (
+	FindingCanonicalInvalid        = "canonical_invalid"
+	FindingCanonicalRelative       = "canonical_relative"
+	FindingCanonicalConflict       = "canonical_conflict"
+	FindingCanonicalMismatch       = "canonical_mismatch"
+	FindingCanonicalTargetError    = "canonical_target_error"
+	FindingCanonicalTargetStatus   = "canonical_target_status"
+	FindingCanonicalTargetRedirect = "canonical_target_redirect"
+	FindingCanonicalTargetNoindex  = "canonical_target_noindex"
+	FindingCanonicalChain          = "canonical_chain"
+	FindingCanonicalLoop           = "canonical_loop"
+)
+
+// maxCanonicalHops — максимальна довжина ланцюжка канонічних посилань, що відстежується
+const maxCanonicalHops = 5
+
+// canonicalLink — канонічне посилання з HTML або заголовка Link
+type canonicalLink struct {
+	Raw      string // Значення href як є
+	Resolved string // Абсолютний URL
+	Source   string // html або header
+}
+
+// canonicalTarget — стан сторінки, на яку вказує канонічне посилання,
+// завантаженої один раз за запуск
+type canonicalTarget struct {
+	once       sync.Once
+	statusCode int
+	redirects  []string
+	noindex    bool
+	canonical  string // Канонічне посилання самої цільової сторінки
+	err        error
+}
+
+// checkCanonical визначає канонічне посилання сторінки та перевіряє його ціль
+func (c *Checker) checkCanonical(ctx context.Context, page *Page, result *PageResult) {
+	links := canonicalLinks(page.FinalURL, page.Response.Header, page.Document)
+
+	var unique []string
+	for _, link := range links {
+		if link.Resolved == "" {
+			result.addFinding(FindingCanonicalInvalid, SeverityError, "", "некоректне канонічне посилання (%s): %q", link.Source, link.Raw)
+			continue
+		}
+		if u, err := url.Parse(link.Raw); err == nil && !u.IsAbs() {
+			result.addFinding(FindingCanonicalRelative, SeverityInfo, link.Resolved, "відносне канонічне посилання %q розв'язано як %s", link.Raw, link.Resolved)
+		}
+		if !containsString(unique, link.Resolved) {
+			unique = append(unique, link.Resolved)
+		}
+	}
+	if len(unique) == 0 {
+		return
+	}
+
+	result.CanonicalURL = unique[0]
+	if len(unique) > 1 {
+		// Пошукові системи ігнорують суперечливі канонічні посилання
+		result.addFinding(FindingCanonicalConflict, SeverityError, "", "кілька різних канонічних посилань: %s", strings.Join(unique, ", "))
+		return
+	}
+
+	canonical := unique[0]
+	if c.cfg.Rewrites.Equal(canonical, page.Entry.Loc) {
+		return
+	}
+	result.addFinding(FindingCanonicalMismatch, SeverityWarning, canonical, "канонічне посилання відрізняється від loc у sitemap: %s", canonical)
+	if !c.cfg.CheckCanonicalTargets {
+		return
+	}
+
+	// Перевіряємо цільову сторінку та відстежуємо ланцюжок канонічних посилань
+	chain := []string{page.Entry.Loc, canonical}
+	// Канонічні посилання розв'язано відносно оригінальних адрес, тож ключі — оригінальні URL
+	seen := map[string]bool{page.Entry.Loc: true}
+	current := canonical
+	for hop := 0; hop < maxCanonicalHops; hop++ {
+		seen[current] = true
+		target := c.canonicalTarget(ctx, current)
+
+		if hop == 0 {
+			switch {
+			case target.err != nil:
+				result.addFinding(FindingCanonicalTargetError, SeverityError, canonical, "не вдалося завантажити канонічну сторінку: %v", target.err)
+				return
+			case len(target.redirects) > 0:
+				result.addFinding(FindingCanonicalTargetRedirect, SeverityError, canonical, "канонічна сторінка перенаправляє на %s", target.redirects[len(target.redirects)-1])
+			case target.statusCode != http.StatusOK:
+				result.addFinding(FindingCanonicalTargetStatus, SeverityError, canonical, "канонічна сторінка повертає статус %d", target.statusCode)
+			}
+			if target.noindex {
+				result.addFinding(FindingCanonicalTargetNoindex, SeverityError, canonical, "канонічна сторінка закрита від індексації (noindex)")
+			}
+		}
+
+		next := target.canonical
+		if target.err != nil || next == "" || c.cfg.Rewrites.Equal(next, current) {
+			break
+		}
+		chain = append(chain, next)
+		if seen[next] {
+			result.addFinding(FindingCanonicalLoop, SeverityError, canonical, "цикл канонічних посилань: %s", strings.Join(chain, " → "))
+			return
+		}
+		current = next
+	}
+
+	if len(chain) > 2 {
+		result.addFinding(FindingCanonicalChain, SeverityWarning, canonical, "ланцюжок канонічних посилань: %s", strings.Join(chain, " → "))
+	}
+}
+
+// canonicalTarget завантажує сторінку за канонічним посиланням (оригінальною
+// адресою) один раз за запуск; правила переписування застосовуються під час завантаження
+func (c *Checker) canonicalTarget(ctx context.Context, targetURL string) *canonicalTarget {
+	c.canonicalMutex.Lock()
+	target, ok := c.canonicalTargets[targetURL]
+	if !ok {
+		target = &canonicalTarget{}
+		c.canonicalTargets[targetURL] = target
+	}
+	c.canonicalMutex.Unlock()
+
+	target.once.Do(func() {
+		resp, err := c.fetcher.Fetch(ctx, &fetcher.Request{URL: c.cfg.Rewrites.Apply(targetURL)})
+		if err != nil {
+			logger.Error("помилка при завантаженні канонічної сторінки %s: %v", targetURL, err)
+			target.err = err
+			return
+		}
+
+		doc := htmldoc.Parse(resp.Body)
+		target.statusCode = resp.StatusCode
+		target.redirects = c.restoreURLs(resp.Redirects)
+		target.noindex = noindexDirective(c.robotsDirectives(resp.Header, doc)) != nil
+		for _, link := range canonicalLinks(c.responseURL(targetURL, resp), resp.Header, doc) {
+			if link.Resolved != "" {
+				target.canonical = link.Resolved
+				break
+			}
+		}
+	})
+	return target
+}
+
+// canonicalLinks збирає канонічні посилання з <head> та заголовка Link; посилання
+// розв'язуються відносно оригінальної адреси сторінки pageURL
+func canonicalLinks(pageURL string, header http.Header, doc *htmldoc.Document) []canonicalLink {
+	var links []canonicalLink
+
+	base := documentBase(pageURL, doc)
+	for _, el := range doc.HeadLinks("canonical") {
+		raw := strings.TrimSpace(el.Attr("href"))
+		links = append(links, canonicalLink{Raw: raw, Resolved: resolveURL(base, raw), Source: "html"})
+	}
+
+	// Посилання із заголовка розв'язуються відносно URL запиту
+	requestURL, _ := url.Parse(pageURL)
+	for _, raw := range linkHeaderURLs(header, "canonical") {
+		links = append(links, canonicalLink{Raw: raw, Resolved: resolveURL(requestURL, raw), Source: "header"})
+	}
+
+	return links
+}
+
+// documentBase повертає базовий URL документа з урахуванням <base href>
+func documentBase(pageURL string, doc *htmldoc.Document) *url.URL {
+	base, err := url.Parse(pageURL)
+	if err != nil {
+		return nil
+	}
+	for _, el := range doc.Find("base") {
+		if href, ok := el.LookupAttr("href"); ok {
+			if ref, err := base.Parse(strings.TrimSpace(href)); err == nil {
+				return ref
+			}
+			break
+		}
+	}
+	return base
+}
+
+// resolveURL перетворює посилання на абсолютний URL без фрагмента;
+// порожній рядок — посилання некоректне
+func resolveURL(base *url.URL, ref string) string {
+	if ref == "" {
+		return ""
+	}
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if !u.IsAbs() || u.Host == "" {
+		return ""
+	}
+	u.Fragment = ""
+	return u.String()
+}
+
+// linkHeaderURLs повертає URL із заголовків Link з вказаним rel
+func linkHeaderURLs(header http.Header, rel string) []string {
+	var result []string
+	for _, value := range header.Values("Link") {
+		for value != "" {
+			start := strings.IndexByte(value, '<')
+			end := strings.IndexByte(value, '>')
+			if start < 0 || end < start {
+				break
+			}
+			target := value[start+1 : end]
+			value = value[end+1:]
+
+			// Параметри до наступного посилання
+			params := value
+			if next := strings.IndexByte(value, '<'); next >= 0 {
+				params = value[:next]
+				value = value[next:]
+			} else {
+				value = ""
+			}
+
+			for _, param := range strings.Split(params, ";") {
+				key, val, ok := strings.Cut(param, "=")
+				if !ok || !strings.EqualFold(strings.TrimSpace(key), "rel") {
+					continue
+				}
+				for _, token := range strings.Fields(strings.Trim(strings.TrimSpace(val), `"`)) {
+					if strings.EqualFold(token, rel) {
+						result = append(result, strings.TrimSpace(target))
+					}
+				}
+			}
+		}
+	}
+	return result
+}
+
+// containsString перевіряє наявність рядка у списку
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package checker
+
+import (
+	"net/http"
+	"testing"
+)
+
+// TestCheckCanonicalTargets перевіряє, що цільова сторінка канонічного посилання
+// завантажується лише з CHECK_CANONICAL_TARGETS
+func TestCheckCanonicalTargets(t *testing.T) {
+	tests := []struct {
+		env      map[string]string
+		fetched  bool
+		findings map[string]bool
+	}{
+		{nil, false, map[string]bool{FindingCanonicalMismatch: true, FindingCanonicalTargetStatus: false, FindingCanonicalChain: false}},
+		{
+			map[string]string{"CHECK_CANONICAL_TARGETS": "true"}, true,
+			map[string]bool{FindingCanonicalMismatch: true, FindingCanonicalTargetStatus: true, FindingCanonicalChain: true},
+		},
+	}
+
+	for _, tt := range tests {
+		f := &fakeFetcher{responses: map[string]fakeResponse{
+			"https://example.com/sitemap.xml": {
+				header: http.Header{"Content-Type": {"application/xml"}},
+				body: `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>https://example.com/a/</loc></url></urlset>`,
+			},
+			"https://example.com/a/": {body: `<html><head><title>Сторінка з канонічним посиланням</title>
+<link rel="canonical" href="https://example.com/b/"></head><body></body></html>`},
+			"https://example.com/b/": {status: http.StatusGone, body: `<html><head><title>Видалена сторінка</title>
+<link rel="canonical" href="https://example.com/c/"></head><body></body></html>`},
+		}}
+		report := runChecker(t, testConfig(t, tt.env), f)
+
+		page := findPage(t, report, "https://example.com/a/")
+		for findingType, want := range tt.findings {
+			if got := hasFinding(page, findingType); got != want {
+				t.Errorf("%v: знахідка %s = %v, очікувалось %v", tt.env, findingType, got, want)
+			}
+		}
+		if got := f.requested("https://example.com/b/"); got != tt.fetched {
+			t.Errorf("%v: канонічну сторінку завантажено = %v, очікувалось %v", tt.env, got, tt.fetched)
+		}
+	}
+}
@@ -5,40 +5,111 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
-	"io"
 	"os"
-	"strings"
 	"sync"
 	"time"
 
 	"sitemap-checker/config"
 	"sitemap-checker/fetcher"
+	"sitemap-checker/htmldoc"
 	"sitemap-checker/logger"
+	"sitemap-checker/normalize"
 	"sitemap-checker/parser"
+	"sitemap-checker/robots"
 )
 
 // PageResult містить результати перевірки сторінки
 type PageResult struct {
-	URL                  string            `json:"url"`
-	StatusCode           int               `json:"status_code"`
-	Redirects            []string          `json:"redirects"`
-	CanonicalURL         string            `json:"canonical_url"`
-	MetaTags             map[string]string `json:"meta_tags"`
-	LoadTime             string            `json:"load_time"`
-	IsBlockedByRobotsTxt bool              `json:"is_blocked_by_robots_txt"`
-	ContentHash          string            `json:"content_hash"`
+	URL                  string                `json:"url"`
+	RewrittenURL         string                `json:"rewritten_url"`
+	Sitemap              string                `json:"sitemap"` // Файл sitemap, у якому знайдено сторінку
+	StatusCode           int                   `json:"status_code"`
+	Redirects            []string              `json:"redirects"`
+	CanonicalURL         string                `json:"canonical_url"`
+	Hreflang             []HreflangLink        `json:"hreflang,omitempty"` // Альтернативні мовні версії з <link rel="alternate" hreflang>
+	MetaTags             map[string]string     `json:"meta_tags"`
+	LoadTime             string                `json:"load_time"`
+	IsBlockedByRobotsTxt bool                  `json:"is_blocked_by_robots_txt"`
+	RobotsRule           *robots.Rule          `json:"robots_rule,omitempty"`
+	ContentType          string                `json:"content_type,omitempty"` // Тип вмісту із заголовка Content-Type
+	Charset              string                `json:"charset,omitempty"`      // Кодування із заголовка Content-Type
+	MetaCharset          string                `json:"meta_charset,omitempty"` // Кодування з <meta charset>
+	Size                 int                   `json:"size"`                   // Розмір відповіді, байт
+	ContentHash          string                `json:"content_hash"`
+	SimHash              string                `json:"simhash,omitempty"`     // Відбиток основного тексту для пошуку майже однакових сторінок
+	WordCount            int                   `json:"word_count"`            // Кількість слів основного тексту
+	ClickDepth           *int                  `json:"click_depth,omitempty"` // Кількість кліків від головної сторінки
+	PageRank             float64               `json:"pagerank,omitempty"`    // Внутрішній PageRank за графом посилань
+	RobotsDirectives     []RobotsDirective     `json:"robots_directives,omitempty"`
+	Indexability         Indexability          `json:"indexability"`
+	OpenGraph            map[string]string     `json:"open_graph,omitempty"`
+	TwitterCard          map[string]string     `json:"twitter_card,omitempty"`
+	OGImage              *ImageInfo            `json:"og_image,omitempty"`
+	StructuredData       []StructuredDataBlock `json:"structured_data,omitempty"`
+	Resources            *PageResources        `json:"resources,omitempty"` // Зображення, стилі, скрипти, шрифти та favicon
+	MixedContent         []MixedContent        `json:"mixed_content,omitempty"`
+	Soft404              *Soft404              `json:"soft_404,omitempty"`
+	SecurityHeaders      map[string]string     `json:"security_headers,omitempty"` // Заголовки безпеки відповіді
+	HSTS                 *HSTS                 `json:"hsts,omitempty"`
+	Caching              *Caching              `json:"caching,omitempty"` // Заголовки кешування та стан кешу CDN
+	Findings             []Finding             `json:"findings"`
+
+	fingerprint uint64 // SimHash у числовому вигляді
+	links       []Link // Внутрішні посилання сторінки
 }
 
-var (
+// Checker перевіряє сторінки з sitemap, завантажуючи їх через Fetcher
+type Checker struct {
+	cfg     *config.Config
+	fetcher fetcher.SiteFetcher
+
 	results      []PageResult // Зберігаємо результати перевірок
 	resultsMutex sync.Mutex   // Для потокобезпечного доступу до results
 
-	contentHashes = make(map[string]string) // Мапа для зберігання хешів контенту
-	hashMutex     sync.Mutex                // Для потокобезпечного доступу до contentHashes
-)
+	robotsHosts map[string]*hostRobots // robots.txt за хостом
+	robotsMutex sync.Mutex             // Для потокобезпечного доступу до robotsHosts
+
+	canonicalTargets map[string]*canonicalTarget // Сторінки, на які вказують канонічні посилання
+	canonicalMutex   sync.Mutex                  // Для потокобезпечного доступу до canonicalTargets
+
+	imageTargets map[string]*imageTarget // Зображення, завантажені для перевірки
+	imageMutex   sync.Mutex              // Для потокобезпечного доступу до imageTargets
+
+	linkTargets map[string]*linkTarget // Внутрішні адреси, на які посилаються сторінки
+	linkMutex   sync.Mutex             // Для потокобезпечного доступу до linkTargets
+
+	assetTargets map[string]*assetTarget // Ресурси сторінок, завантажені для перевірки
+	assetMutex   sync.Mutex              // Для потокобезпечного доступу до assetTargets
+
+	soft404Hosts map[string]*soft404Host // Відповіді хостів для неіснуючих адрес
+	soft404Mutex sync.Mutex              // Для потокобезпечного доступу до soft404Hosts
+}
+
+// New створює Checker з вказаною конфігурацією та джерелом завантаження
+// (зазвичай *fetcher.CachedFetcher)
+func New(cfg *config.Config, f fetcher.SiteFetcher) *Checker {
+	return &Checker{
+		cfg:         cfg,
+		fetcher:     f,
+		robotsHosts: make(map[string]*hostRobots),
+
+		canonicalTargets: make(map[string]*canonicalTarget),
+		imageTargets:     make(map[string]*imageTarget),
+		linkTargets:      make(map[string]*linkTarget),
+		assetTargets:     make(map[string]*assetTarget),
+		soft404Hosts:     make(map[string]*soft404Host),
+	}
+}
+
+// Results повертає копію зібраних результатів перевірки
+func (c *Checker) Results() []PageResult {
+	c.resultsMutex.Lock()
+	defer c.resultsMutex.Unlock()
+	return append([]PageResult(nil), c.results...)
+}
 
 // ProcessURLSet обробляє список URL-адрес
-func ProcessURLSet(ctx context.Context, urlset *parser.URLSet, wg *sync.WaitGroup, sem chan struct{}, cfg *config.Config) {
+func (c *Checker) ProcessURLSet(ctx context.Context, urlset *parser.URLSet, wg *sync.WaitGroup, sem chan struct{}) {
 	defer wg.Done()
 
 	for _, url := range urlset.URLs {
@@ -50,112 +121,24 @@ func ProcessURLSet(ctx context.Context, urlset *parser.URLSet, wg *sync.WaitGrou
 			sem <- struct{}{}
 			wg.Add(1)
 			go func(url parser.URL) {
+				defer wg.Done()
 				defer func() { <-sem }()
 
-				// Перевірка robots.txt
-				isAllowed := CheckRobotsTxt(ctx, url.Loc)
-
-				// Завантажуємо сторінку з вимірюванням часу
-				resp, redirects, loadTime, err := fetcher.FetchPageWithTiming(ctx, url.Loc, cfg.MaxRedirects)
+				pageResult, err := c.checkPage(ctx, url, urlset.Source)
 				if err != nil {
 					logger.Error("помилка при завантаженні сторінки %s: %v", url.Loc, err)
-					wg.Done()
 					return
 				}
-				defer func(Body io.ReadCloser) {
-					err := Body.Close()
-					if err != nil {
-						logger.Error("помилка при закритті тіла відповіді: %v", err)
-					}
-				}(resp.Body)
-
-				// Перевірка часу завантаження
-				CheckPageLoadTime(url.Loc, loadTime, 2*time.Second) // Поріг: 2 секунди
-
-				// Читання тіла сторінки
-				body, err := io.ReadAll(resp.Body)
-				if err != nil {
-					logger.Error("помилка при читанні тіла сторінки: %v", err)
-					wg.Done()
-					return
-				}
-
-				// Перевірка на дублі контенту
-				contentHash := CheckContentDuplicates(string(body), url.Loc)
-
-				// Збір даних про сторінку
-				pageResult := PageResult{
-					URL:                  url.Loc,
-					StatusCode:           resp.StatusCode,
-					Redirects:            redirects,
-					CanonicalURL:         extractCanonicalURL(string(body)),
-					MetaTags:             extractMetaTags(string(body)),
-					LoadTime:             loadTime.String(),
-					IsBlockedByRobotsTxt: !isAllowed,
-					ContentHash:          contentHash,
-				}
 
 				// Зберігаємо результат
-				resultsMutex.Lock()
-				results = append(results, pageResult)
-				resultsMutex.Unlock()
-
-				wg.Done()
+				c.resultsMutex.Lock()
+				c.results = append(c.results, *pageResult)
+				c.resultsMutex.Unlock()
 			}(url)
 		}
 	}
 }
 
-// extractCanonicalURL витягує канонічне посилання з HTML
-func extractCanonicalURL(html string) string {
-	if strings.Contains(html, `<link rel="canonical"`) {
-		// Проста реалізація для прикладу
-		start := strings.Index(html, `href="`) + 6
-		end := strings.Index(html[start:], `"`) + start
-		return html[start:end]
-	}
-	return ""
-}
-
-// extractMetaTags витягує мета-теги з HTML
-func extractMetaTags(html string) map[string]string {
-	metaTags := make(map[string]string)
-
-	// Вилучення тегу <title>
-	if titleStart := strings.Index(html, "<title>"); titleStart != -1 {
-		titleEnd := strings.Index(html[titleStart:], "</title>")
-		if titleEnd != -1 {
-			metaTags["title"] = html[titleStart+7 : titleStart+titleEnd]
-		}
-	}
-
-	// Вилучення мета-тегу description
-	if descStart := strings.Index(html, `<meta name="description"`); descStart != -1 {
-		contentStart := strings.Index(html[descStart:], `content="`) + descStart + 9
-		contentEnd := strings.Index(html[contentStart:], `"`) + contentStart
-		metaTags["description"] = html[contentStart:contentEnd]
-	}
-
-	return metaTags
-}
-
-// CheckRobotsTxt перевіряє, чи сторінка дозволена в robots.txt
-func CheckRobotsTxt(ctx context.Context, pageURL string) bool {
-	robotsTxt, err := fetcher.FetchRobotsTxt(ctx, pageURL)
-	if err != nil {
-		logger.Error("помилка при завантаженні robots.txt: %v", err)
-		return true // Якщо robots.txt недоступний, вважаємо сторінку дозволеною
-	}
-
-	// Перевіряємо, чи сторінка дозволена
-	if strings.Contains(string(robotsTxt), "Disallow: "+pageURL) {
-		logger.Error("сторінка заблокована в robots.txt: %s", pageURL)
-		return false
-	}
-
-	return true
-}
-
 // CheckPageLoadTime перевіряє час завантаження сторінки
 func CheckPageLoadTime(pageURL string, loadTime time.Duration, threshold time.Duration) {
 	if loadTime > threshold {
@@ -165,26 +148,21 @@ func CheckPageLoadTime(pageURL string, loadTime time.Duration, threshold time.Du
 	}
 }
 
-// CheckContentDuplicates перевіряє наявність дублів контенту
-func CheckContentDuplicates(content string, pageURL string) string {
-	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(content)))
-
-	hashMutex.Lock()
-	defer hashMutex.Unlock()
+// ContentHash обчислює хеш вмісту сторінки після нормалізації
+func ContentHash(doc *htmldoc.Document, pipeline *normalize.Pipeline) string {
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(pipeline.Apply(doc))))
+}
 
-	if existingURL, exists := contentHashes[hash]; exists {
-		logger.Error("дубль контенту: %s та %s", pageURL, existingURL)
-	} else {
-		contentHashes[hash] = pageURL
-	}
-	return hash
+// BodyHash обчислює хеш тіла відповіді як є — для документів, що не є HTML
+func BodyHash(body []byte) string {
+	return fmt.Sprintf("%x", sha256.Sum256(body))
 }
 
 // ProcessSitemapIndex обробляє вкладені файли sitemap
-func ProcessSitemapIndex(ctx context.Context, sitemapIndex *parser.SitemapIndex, depth int, wg *sync.WaitGroup, sem chan struct{}, cfg *config.Config) {
+func (c *Checker) ProcessSitemapIndex(ctx context.Context, sitemapIndex *parser.SitemapIndex, depth int, wg *sync.WaitGroup, sem chan struct{}) {
 	defer wg.Done()
 
-	if depth > cfg.MaxDepth {
+	if depth > c.cfg.MaxDepth {
 		logger.Error("досягнуто максимальну глибину рекурсії: %d", depth)
 		return
 	}
@@ -201,7 +179,7 @@ func ProcessSitemapIndex(ctx context.Context, sitemapIndex *parser.SitemapIndex,
 				defer func() { <-sem }()
 
 				// Завантажуємо та обробляємо кожен файл sitemap
-				data, err := fetcher.FetchSitemap(ctx, sitemap.Loc)
+				data, err := c.fetcher.FetchSitemap(ctx, c.cfg.Rewrites.Apply(sitemap.Loc))
 				if err != nil {
 					logger.Error("помилка при завантаженні файлу sitemap %s: %v", sitemap.Loc, err)
 					wg.Done()
@@ -217,11 +195,12 @@ func ProcessSitemapIndex(ctx context.Context, sitemapIndex *parser.SitemapIndex,
 
 				switch content := sitemapContent.(type) {
 				case *parser.URLSet:
+					content.Source = sitemap.Loc
 					wg.Add(1)
-					ProcessURLSet(ctx, content, wg, sem, cfg)
+					c.ProcessURLSet(ctx, content, wg, sem)
 				case *parser.SitemapIndex:
 					wg.Add(1)
-					ProcessSitemapIndex(ctx, content, depth+1, wg, sem, cfg)
+					c.ProcessSitemapIndex(ctx, content, depth+1, wg, sem)
 				default:
 					logger.Error("невідомий тип вмісту sitemap: %s", sitemap.Loc)
 				}
@@ -231,10 +210,9 @@ func ProcessSitemapIndex(ctx context.Context, sitemapIndex *parser.SitemapIndex,
 	}
 }
 
-// SaveResultsToJSON зберігає результати у JSON-файл
-func SaveResultsToJSON(filename string) error {
-	resultsMutex.Lock()
-	defer resultsMutex.Unlock()
+// SaveResultsToJSON зберігає звіт у JSON-файл
+func (c *Checker) SaveResultsToJSON(filename string) error {
+	report := c.Report()
 
 	file, err := os.Create(filename)
 	if err != nil {
@@ -249,7 +227,7 @@ func SaveResultsToJSON(filename string) error {
 
 	encoder := json.NewEncoder(file)
 	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(results); err != nil {
+	if err := encoder.Encode(report); err != nil {
 		return fmt.Errorf("помилка при записі JSON: %v", err)
 	}
 
@@ -0,0 +1,308 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"testing"
+
+	"sitemap-checker/cache"
+	"sitemap-checker/config"
+	"sitemap-checker/fetcher"
+	"sitemap-checker/logger"
+	"sitemap-checker/parser"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "checker-test-")
+	if err != nil {
+		panic(err)
+	}
+	logger.Init(filepath.Join(dir, "errors.log"))
+	code := m.Run()
+	logger.Close()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// fakeFetcher віддає відповіді із заданого набору; невідомі адреси — 404
+type fakeFetcher struct {
+	mu        sync.Mutex
+	responses map[string]fakeResponse
+	requests  []string
+}
+
+type fakeResponse struct {
+	status int
+	header http.Header
+	body   string
+}
+
+func (f *fakeFetcher) Fetch(ctx context.Context, req *fetcher.Request) (*fetcher.Response, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests = append(f.requests, req.URL)
+
+	resp := &fetcher.Response{URL: req.URL, StatusCode: http.StatusNotFound, Header: make(http.Header)}
+	r, ok := f.responses[req.URL]
+	if !ok {
+		return resp, nil
+	}
+
+	resp.StatusCode = r.status
+	if resp.StatusCode == 0 {
+		resp.StatusCode = http.StatusOK
+	}
+	for key, values := range r.header {
+		resp.Header[key] = values
+	}
+	if resp.Header.Get("Content-Type") == "" {
+		resp.Header.Set("Content-Type", "text/html; charset=utf-8")
+	}
+	resp.Body = []byte(r.body)
+	return resp, nil
+}
+
+// requested повідомляє, чи завантажувалась адреса
+func (f *fakeFetcher) requested(rawURL string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, u := range f.requests {
+		if u == rawURL {
+			return true
+		}
+	}
+	return false
+}
+
+// testConfig завантажує конфігурацію зі змінних оточення env поверх значень за замовчуванням
+func testConfig(t *testing.T, env map[string]string) *config.Config {
+	t.Helper()
+	t.Setenv("SITEMAP_URL", "https://example.com/sitemap.xml")
+	for key, value := range env {
+		t.Setenv(key, value)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	return cfg
+}
+
+// runChecker виконує повну перевірку так само, як main: sitemap → сторінки → обхід → звіт
+func runChecker(t *testing.T, cfg *config.Config, f *fakeFetcher) *Report {
+	t.Helper()
+	ctx := context.Background()
+	cf := fetcher.NewCachedFetcher(f, cache.NewMemory(100), fetcher.CacheTTL{})
+	chk := New(cfg, cf)
+
+	data, err := cf.FetchSitemap(ctx, cfg.Rewrites.Apply(cfg.SitemapURL))
+	if err != nil {
+		t.Fatalf("FetchSitemap: %v", err)
+	}
+	content, err := parser.ParseSitemap(data)
+	if err != nil {
+		t.Fatalf("ParseSitemap: %v", err)
+	}
+	urlset, ok := content.(*parser.URLSet)
+	if !ok {
+		t.Fatalf("очікувався urlset, отримано %T", content)
+	}
+	urlset.Source = cfg.SitemapURL
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	chk.ProcessURLSet(ctx, urlset, &wg, make(chan struct{}, cfg.MaxGoroutines))
+	wg.Wait()
+	chk.Crawl(ctx)
+
+	report := chk.Report()
+	sort.Slice(report.Pages, func(i, j int) bool { return report.Pages[i].URL < report.Pages[j].URL })
+	return report
+}
+
+// findPage повертає результат сторінки з оригінальною адресою pageURL
+func findPage(t *testing.T, report *Report, pageURL string) *PageResult {
+	t.Helper()
+	for i := range report.Pages {
+		if report.Pages[i].URL == pageURL {
+			return &report.Pages[i]
+		}
+	}
+	t.Fatalf("сторінку %s не перевірено", pageURL)
+	return nil
+}
+
+// hasFinding повідомляє, чи має сторінка знахідку вказаного типу
+func hasFinding(page *PageResult, findingType string) bool {
+	for _, finding := range page.Findings {
+		if finding.Type == findingType {
+			return true
+		}
+	}
+	return false
+}
+
+// depthOf повертає глибину кліків сторінки; -1 — сторінка недосяжна
+func depthOf(page *PageResult) int {
+	if page.ClickDepth == nil {
+		return -1
+	}
+	return *page.ClickDepth
+}
+
+// testSite — невеликий сайт: головна посилається на /a/ та /b/, /a/ — на
+// неіснуючу /nope/, /c/ є лише в sitemap, а канонічне посилання /b/ веде на /a/
+func testSite(prefix string) map[string]fakeResponse {
+	return map[string]fakeResponse{
+		"https://example.com/robots.txt": {header: http.Header{"Content-Type": {"text/plain"}}, body: "User-agent: *\nAllow: /\n"},
+		"https://example.com" + prefix + "sitemap.xml": {
+			header: http.Header{"Content-Type": {"application/xml"}},
+			body: `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+<url><loc>https://example.com/</loc></url>
+<url><loc>https://example.com/a/</loc></url>
+<url><loc>https://example.com/b/</loc></url>
+<url><loc>https://example.com/c/</loc></url>
+</urlset>`,
+		},
+		"https://example.com" + prefix: {body: `<html><head><title>Головна сторінка сайту</title></head><body>
+<a href="/a/">Розділ A</a> <a href="b/">Розділ B</a></body></html>`},
+		"https://example.com" + prefix + "a/": {body: `<html><head><title>Розділ A на сайті</title></head><body>
+<a href="/nope/">Зламане посилання</a> <a href="/">Головна</a></body></html>`},
+		"https://example.com" + prefix + "b/": {body: `<html><head><title>Розділ B на сайті</title>
+<link rel="canonical" href="/a/"></head><body>Текст</body></html>`},
+		"https://example.com" + prefix + "c/": {body: `<html><head><title>Сторінка C без посилань</title></head><body>Текст</body></html>`},
+	}
+}
+
+func TestCheckerSitemapPages(t *testing.T) {
+	cfg := testConfig(t, map[string]string{"CHECK_LINKS": "true", "CRAWL_MAX_DEPTH": "5"})
+	f := &fakeFetcher{responses: testSite("/")}
+	report := runChecker(t, cfg, f)
+
+	if len(report.Pages) != 4 {
+		t.Fatalf("перевірено %d сторінок, очікувалось 4", len(report.Pages))
+	}
+
+	tests := []struct {
+		url     string
+		finding string
+		want    bool
+	}{
+		{"https://example.com/a/", FindingBrokenLink, true},
+		{"https://example.com/b/", FindingCanonicalMismatch, true},
+		{"https://example.com/c/", FindingOrphanPage, true},
+		{"https://example.com/a/", FindingOrphanPage, false},
+		{"https://example.com/b/", FindingOrphanPage, false},
+		{"https://example.com/", FindingOrphanPage, false},
+	}
+	for _, tt := range tests {
+		if got := hasFinding(findPage(t, report, tt.url), tt.finding); got != tt.want {
+			t.Errorf("%s: знахідка %s = %v, очікувалось %v", tt.url, tt.finding, got, tt.want)
+		}
+	}
+
+	if len(report.BrokenLinks) != 1 || report.BrokenLinks[0].URL != "https://example.com/nope/" {
+		t.Errorf("broken_links = %+v, очікувалась https://example.com/nope/", report.BrokenLinks)
+	}
+	if got := report.ClickDepth.Unreachable; len(got) != 1 || got[0] != "https://example.com/c/" {
+		t.Errorf("unreachable = %v, очікувалось [https://example.com/c/]", got)
+	}
+	if depth := depthOf(findPage(t, report, "https://example.com/a/")); depth != 1 {
+		t.Errorf("глибина /a/ = %d, очікувалось 1", depth)
+	}
+}
+
+// TestCheckerRewritePaths перевіряє, що з REWRITE_PATHS=/=/preview/ сторінки
+// завантажуються з /preview/ рівно один раз, а покриття, глибина та PageRank
+// такі самі, як без переписування
+func TestCheckerRewritePaths(t *testing.T) {
+	plain := runChecker(t, testConfig(t, map[string]string{"CHECK_LINKS": "true", "CHECK_CANONICAL_TARGETS": "true"}), &fakeFetcher{responses: testSite("/")})
+
+	f := &fakeFetcher{responses: testSite("/preview/")}
+	rewritten := runChecker(t, testConfig(t, map[string]string{"CHECK_LINKS": "true", "CHECK_CANONICAL_TARGETS": "true", "REWRITE_PATHS": "/=/preview/"}), f)
+
+	if f.requested("https://example.com/preview/preview/") {
+		t.Errorf("URL переписано двічі: завантажено /preview/preview/")
+	}
+	if !f.requested("https://example.com/preview/nope/") {
+		t.Errorf("посилання під час обходу не переписано: /preview/nope/ не завантажувалась")
+	}
+
+	if len(rewritten.Pages) != len(plain.Pages) {
+		t.Fatalf("перевірено %d сторінок, очікувалось %d", len(rewritten.Pages), len(plain.Pages))
+	}
+	for i := range plain.Pages {
+		want, got := &plain.Pages[i], &rewritten.Pages[i]
+		if got.RewrittenURL != "https://example.com/preview"+want.URL[len("https://example.com"):] {
+			t.Errorf("%s: rewritten_url = %s", got.URL, got.RewrittenURL)
+		}
+		for _, findingType := range []string{FindingOrphanPage, FindingBrokenLink, FindingCanonicalMismatch, FindingCanonicalTargetStatus} {
+			if hasFinding(got, findingType) != hasFinding(want, findingType) {
+				t.Errorf("%s: знахідка %s = %v, без переписування %v", got.URL, findingType, hasFinding(got, findingType), hasFinding(want, findingType))
+			}
+		}
+		if depthOf(got) != depthOf(want) {
+			t.Errorf("%s: глибина %d, без переписування %d", got.URL, depthOf(got), depthOf(want))
+		}
+		if got.PageRank != want.PageRank {
+			t.Errorf("%s: PageRank %v, без переписування %v", got.URL, got.PageRank, want.PageRank)
+		}
+	}
+}
+
+// TestCheckerRealTargetPrefix перевіряє, що справжня адреса з префіксом
+// призначення (/news/ за правила /blog/=/news/) не вважається переписаною
+func TestCheckerRealTargetPrefix(t *testing.T) {
+	cfg := testConfig(t, map[string]string{"CHECK_LINKS": "true", "REWRITE_PATHS": "/blog/=/news/"})
+	f := &fakeFetcher{responses: map[string]fakeResponse{
+		"https://example.com/sitemap.xml": {
+			header: http.Header{"Content-Type": {"application/xml"}},
+			body: `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+<url><loc>https://example.com/</loc></url>
+<url><loc>https://example.com/blog/a/</loc></url>
+</urlset>`,
+		},
+		"https://example.com/": {body: `<html><head><title>Головна сторінка сайту</title></head><body>
+<a href="/blog/a/">Запис блогу</a> <a href="/news/x/">Новина</a></body></html>`},
+		"https://example.com/news/a/": {body: `<html><head><title>Запис блогу на сайті</title>
+<link rel="canonical" href="https://example.com/blog/a/"></head><body><a href="/">Головна</a></body></html>`},
+		"https://example.com/news/x/": {body: `<html><head><title>Новина на сайті</title></head><body><a href="/">Головна</a></body></html>`},
+	}}
+	report := runChecker(t, cfg, f)
+
+	if len(report.BrokenLinks) != 0 {
+		t.Errorf("broken_links = %+v, очікувалось порожньо", report.BrokenLinks)
+	}
+	if hasFinding(findPage(t, report, "https://example.com/blog/a/"), FindingCanonicalMismatch) {
+		t.Errorf("канонічне посилання /blog/a/ вважається невідповідним loc")
+	}
+	missing := report.Coverage.MissingFromSitemap
+	if len(missing) != 1 || missing[0].URL != "https://example.com/news/x/" {
+		t.Errorf("missing_from_sitemap = %+v, очікувалось https://example.com/news/x/", missing)
+	}
+}
+
+// TestCheckerBrokenLinksWithoutCrawl перевіряє, що посилання зі сторінок sitemap
+// перевіряються без обходу та за нульової глибини обходу
+func TestCheckerBrokenLinksWithoutCrawl(t *testing.T) {
+	for _, env := range []map[string]string{
+		nil,
+		{"CHECK_LINKS": "true", "CRAWL_MAX_DEPTH": "0"},
+	} {
+		f := &fakeFetcher{responses: testSite("/")}
+		report := runChecker(t, testConfig(t, env), f)
+
+		if len(report.BrokenLinks) != 1 || report.BrokenLinks[0].URL != "https://example.com/nope/" {
+			t.Errorf("%v: broken_links = %+v, очікувалась https://example.com/nope/", env, report.BrokenLinks)
+		}
+		if !hasFinding(findPage(t, report, "https://example.com/a/"), FindingBrokenLink) {
+			t.Errorf("%v: сторінка /a/ без знахідки broken_link", env)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package checker
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"strings"
+
+	"sitemap-checker/htmldoc"
+)
+
+// Типи знахідок щодо типу вмісту та кодування
+const (
+	FindingContentTypeMissing = "content_type_missing"
+	FindingCharsetMissing     = "charset_missing"
+	FindingCharsetMismatch    = "charset_mismatch"
+	FindingCharsetUnknown     = "charset_unknown"
+	FindingDocumentEmpty      = "document_empty"
+)
+
+// charsetAliases — поширені написання кодувань, що позначають те саме кодування
+var charsetAliases = map[string]string{
+	"utf8":   "utf-8",
+	"latin1": "iso-8859-1",
+	"cp1251": "windows-1251",
+}
+
+// knownCharsets — назви кодувань зі стандарту WHATWG Encoding та поширені синоніми,
+// які браузери розпізнають; інші назви браузер ігнорує
+var knownCharsets = map[string]bool{
+	"utf-8": true, "utf-16": true, "utf-16be": true, "utf-16le": true, "us-ascii": true,
+	"ibm866": true, "iso-8859-1": true, "iso-8859-2": true, "iso-8859-3": true, "iso-8859-4": true,
+	"iso-8859-5": true, "iso-8859-6": true, "iso-8859-7": true, "iso-8859-8": true, "iso-8859-8-i": true,
+	"iso-8859-9": true, "iso-8859-10": true, "iso-8859-11": true, "iso-8859-13": true, "iso-8859-14": true,
+	"iso-8859-15": true, "iso-8859-16": true, "koi8-r": true, "koi8-u": true, "macintosh": true,
+	"x-mac-cyrillic": true, "windows-874": true, "windows-1250": true, "windows-1251": true,
+	"windows-1252": true, "windows-1253": true, "windows-1254": true, "windows-1255": true,
+	"windows-1256": true, "windows-1257": true, "windows-1258": true, "gbk": true, "gb2312": true,
+	"gb18030": true, "big5": true, "euc-jp": true, "iso-2022-jp": true, "shift_jis": true,
+	"euc-kr": true, "x-user-defined": true,
+}
+
+// checkContentType записує тип вмісту, кодування та розмір відповіді; для HTML
+// перевіряє наявність кодування та його збіг у заголовку і <meta>
+func (c *Checker) checkContentType(ctx context.Context, page *Page, result *PageResult) {
+	header := page.Response.Header.Get("Content-Type")
+	result.Size = len(page.Response.Body)
+
+	mediaType, params, err := mime.ParseMediaType(header)
+	if err == nil {
+		result.ContentType = mediaType
+		result.Charset = normalizeCharset(params["charset"])
+	}
+	result.MetaCharset = metaCharset(page.Document) // Для документів, що не є HTML, документ порожній
+
+	// Відповіді з помилками та редіректами лише записуються
+	if page.Response.StatusCode != http.StatusOK {
+		return
+	}
+
+	switch {
+	case header == "":
+		result.addFinding(FindingContentTypeMissing, SeverityWarning, "", "відповідь без заголовка Content-Type")
+	case err != nil:
+		result.addFinding(FindingContentTypeMissing, SeverityWarning, "", "некоректний заголовок Content-Type: %q", header)
+	}
+	if result.Size == 0 {
+		result.addFinding(FindingDocumentEmpty, SeverityError, "", "відповідь зі статусом 200 без вмісту")
+	}
+	if !isHTML(result.ContentType) {
+		return
+	}
+
+	for _, charset := range []struct{ source, name string }{{"Content-Type", result.Charset}, {"<meta>", result.MetaCharset}} {
+		if charset.name != "" && !knownCharsets[charset.name] {
+			result.addFinding(FindingCharsetUnknown, SeverityError, "", "невідоме кодування в %s: %q", charset.source, charset.name)
+		}
+	}
+
+	switch {
+	case result.Charset == "" && result.MetaCharset == "":
+		result.addFinding(FindingCharsetMissing, SeverityWarning, "", "кодування не вказано ні в Content-Type, ні в <meta charset>")
+	case result.Charset != "" && result.MetaCharset != "" && result.Charset != result.MetaCharset:
+		result.addFinding(FindingCharsetMismatch, SeverityError, "", "кодування в Content-Type (%s) не збігається з <meta> (%s)", result.Charset, result.MetaCharset)
+	}
+}
+
+// metaCharset повертає кодування з <meta charset> або <meta http-equiv="Content-Type">
+func metaCharset(doc *htmldoc.Document) string {
+	for _, el := range doc.Find("meta") {
+		if charset, ok := el.LookupAttr("charset"); ok {
+			return normalizeCharset(charset)
+		}
+		if strings.EqualFold(strings.TrimSpace(el.Attr("http-equiv")), "content-type") {
+			if _, params, err := mime.ParseMediaType(el.Attr("content")); err == nil {
+				return normalizeCharset(params["charset"])
+			}
+		}
+	}
+	return ""
+}
+
+// normalizeCharset приводить назву кодування до нижнього регістру та канонічного написання
+func normalizeCharset(charset string) string {
+	charset = strings.ToLower(strings.Trim(strings.TrimSpace(charset), `"'`))
+	if alias, ok := charsetAliases[charset]; ok {
+		return alias
+	}
+	return charset
+}
+
+// isHTML повідомляє, чи позначає тип вмісту HTML-документ; відсутній Content-Type вважається HTML
+func isHTML(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return contentType == "" || strings.Contains(contentType, "html")
+}
@@ -0,0 +1,41 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"sitemap-checker/fetcher"
+	"sitemap-checker/htmldoc"
+)
+
+func TestCheckContentTypeCharset(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		findings    map[string]bool
+	}{
+		{"відоме кодування", "text/html; charset=UTF-8", `<meta charset="utf-8">`, map[string]bool{FindingCharsetUnknown: false, FindingCharsetMismatch: false}},
+		{"синонім кодування", "text/html; charset=utf8", `<meta charset="UTF-8">`, map[string]bool{FindingCharsetUnknown: false, FindingCharsetMismatch: false}},
+		{"невідоме кодування в заголовку", "text/html; charset=foo", `<p>Текст</p>`, map[string]bool{FindingCharsetUnknown: true, FindingCharsetMissing: false}},
+		{"невідоме кодування в meta", "text/html", `<meta charset="utf-9">`, map[string]bool{FindingCharsetUnknown: true}},
+		{"кодування не HTML не перевіряється", "text/plain; charset=foo", `текст`, map[string]bool{FindingCharsetUnknown: false}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &Page{
+				Response: &fetcher.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {tt.contentType}}, Body: []byte(tt.body)},
+				Document: htmldoc.Parse([]byte(tt.body)),
+			}
+			result := &PageResult{}
+			(&Checker{}).checkContentType(context.Background(), page, result)
+			for findingType, want := range tt.findings {
+				if got := hasFinding(result, findingType); got != want {
+					t.Errorf("знахідка %s = %v, очікувалось %v", findingType, got, want)
+				}
+			}
+		})
+	}
+}
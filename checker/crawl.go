@@ -0,0 +1,350 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"sitemap-checker/fetcher"
+	"sitemap-checker/htmldoc"
+	"sitemap-checker/logger"
+)
+
+// Типи знахідок щодо покриття sitemap
+const (
+	FindingOrphanPage = "orphan_page"
+)
+
+// Області обходу
+const (
+	ScopeHost   = "host"   // Лише хост сторінки
+	ScopeDomain = "domain" // Хост сторінки та його піддомени
+)
+
+// linkTarget — внутрішня адреса, завантажена один раз за запуск
+type linkTarget struct {
+	once       sync.Once
+	url        string // Оригінальна адреса (до переписування): loc для сторінок з sitemap
+	statusCode int
+	redirects  []string // Адреси редіректів, зведені до оригінальних URL
+	blocked    bool     // Заблокована в robots.txt і не завантажувалась
+	err        error
+	indexable  bool
+	crawled    bool   // Посилання сторінки розібрано
+	unexpanded bool   // HTML-сторінка на граничній глибині: посилання не розбиралися
+	links      []Link // Внутрішні посилання, якщо сторінку розібрано
+	inSitemap  bool
+}
+
+// broken повідомляє, чи адреса недоступна
+func (t *linkTarget) broken() bool {
+	return !t.blocked && (t.err != nil || t.statusCode >= 400)
+}
+
+// MissingPage — сторінка, знайдена за посиланнями, але відсутня в sitemap
+type MissingPage struct {
+	URL     string       `json:"url"`
+	Sources []LinkSource `json:"sources"`
+}
+
+// Coverage — порівняння sitemap зі сторінками, знайденими за посиланнями
+type Coverage struct {
+	Orphans            []string      `json:"orphans"`              // Сторінки з sitemap, на які немає внутрішніх посилань
+	MissingFromSitemap []MissingPage `json:"missing_from_sitemap"` // Сторінки, що індексуються, але відсутні в sitemap
+}
+
+// Crawl перевіряє внутрішні посилання сторінок sitemap, а з CHECK_LINKS обходить
+// сайт від головної сторінки та сторінок sitemap на глибину CRAWL_MAX_DEPTH;
+// адреси завантажуються тим самим Fetcher з урахуванням robots.txt та обмеження паралельності
+func (c *Checker) Crawl(ctx context.Context) {
+	if c.cfg.CheckLinks {
+		c.crawlSite(ctx)
+	}
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Цілі посилань зі сторінок sitemap завантажуються завжди, незалежно від обходу
+	// та його глибини; адреси, вже завантажені під час обходу, повторно не запитуються
+	seen := make(map[string]bool)
+	var targets []string
+	for _, page := range c.Results() {
+		for _, link := range page.links {
+			if !seen[link.URL] {
+				seen[link.URL] = true
+				targets = append(targets, link.URL)
+			}
+		}
+	}
+	c.fetchTargets(ctx, targets, false)
+	if ctx.Err() != nil {
+		logger.Error("перевірку посилань перервано через скасування контексту")
+	}
+}
+
+// crawlSite обходить сайт у ширину від головної сторінки та сторінок sitemap на глибину CRAWL_MAX_DEPTH
+func (c *Checker) crawlSite(ctx context.Context) {
+	seen := make(map[string]bool)
+	var frontier []string
+	// Черга й записи адрес — в оригінальних URL; переписуються вони лише під час завантаження
+	enqueue := func(key string) {
+		if !seen[key] {
+			seen[key] = true
+			frontier = append(frontier, key)
+		}
+	}
+
+	// Початкові сторінки: головна та всі сторінки з sitemap
+	if home := siteRoot(c.cfg.SitemapURL); home != "" {
+		enqueue(home)
+	}
+	for _, page := range c.Results() {
+		enqueue(page.URL)
+	}
+
+	for depth := 0; len(frontier) > 0; depth++ {
+		level := frontier
+		frontier = nil
+
+		// Сторінки на граничній глибині лише перевіряються, їхні посилання не розбираються
+		expand := depth < c.cfg.CrawlMaxDepth
+		c.fetchTargets(ctx, level, expand)
+		if ctx.Err() != nil {
+			logger.Error("обхід сайту перервано через скасування контексту")
+			return
+		}
+		if !expand {
+			break
+		}
+
+		for _, key := range level {
+			target := c.linkTarget(key)
+			for _, link := range target.links {
+				enqueue(link.URL)
+			}
+		}
+	}
+}
+
+// fetchTargets паралельно завантажує адреси одного рівня обходу
+func (c *Checker) fetchTargets(ctx context.Context, targets []string, expand bool) {
+	sem := make(chan struct{}, c.cfg.MaxGoroutines)
+	var wg sync.WaitGroup
+	for _, targetURL := range targets {
+		select {
+		case <-ctx.Done():
+			wg.Wait()
+			return
+		case sem <- struct{}{}:
+		}
+
+		wg.Add(1)
+		go func(targetURL string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			c.fetchTarget(ctx, targetURL, expand)
+		}(targetURL)
+	}
+	wg.Wait()
+}
+
+// linkTarget повертає запис для внутрішньої адреси, створюючи його за потреби;
+// записи зберігаються за оригінальними адресами
+func (c *Checker) linkTarget(key string) *linkTarget {
+	c.linkMutex.Lock()
+	defer c.linkMutex.Unlock()
+
+	target, ok := c.linkTargets[key]
+	if !ok {
+		target = &linkTarget{url: key}
+		c.linkTargets[key] = target
+	}
+	return target
+}
+
+// fetchTarget завантажує внутрішню адресу один раз за запуск; expand — розібрати
+// посилання сторінки для продовження обходу. Правила переписування
+// застосовуються лише тут, до адреси завантаження
+func (c *Checker) fetchTarget(ctx context.Context, targetURL string, expand bool) *linkTarget {
+	target := c.linkTarget(targetURL)
+	target.once.Do(func() {
+		fetchURL := c.cfg.Rewrites.Apply(target.url)
+		if r := c.robotsFor(ctx, fetchURL); r != nil && !r.Test(c.cfg.UserAgent, fetchURL).Allowed {
+			target.blocked = true
+			return
+		}
+
+		resp, err := c.fetcher.Fetch(ctx, &fetcher.Request{URL: fetchURL})
+		if err != nil {
+			logger.Error("помилка при перевірці посилання %s: %v", fetchURL, err)
+			target.err = err
+			return
+		}
+		target.statusCode = resp.StatusCode
+		target.redirects = c.restoreURLs(resp.Redirects)
+
+		if !isHTML(resp.Header.Get("Content-Type")) {
+			return
+		}
+		doc := htmldoc.Parse(resp.Body)
+		pageURL := c.responseURL(target.url, resp)
+		target.indexable = c.crawledIndexable(pageURL, resp, doc)
+		if expand {
+			target.links = c.internalLinks(pageURL, doc)
+			target.crawled = true
+		} else {
+			target.unexpanded = true
+		}
+	})
+	return target
+}
+
+// crawledIndexable визначає, чи індексується сторінка з оригінальною адресою
+// pageURL, знайдена під час обходу
+func (c *Checker) crawledIndexable(pageURL string, resp *fetcher.Response, doc *htmldoc.Document) bool {
+	if resp.StatusCode != http.StatusOK || len(resp.Redirects) > 0 {
+		return false
+	}
+	if noindexDirective(c.robotsDirectives(resp.Header, doc)) != nil {
+		return false
+	}
+	for _, link := range canonicalLinks(pageURL, resp.Header, doc) {
+		if link.Resolved != "" && link.Resolved != pageURL {
+			return false
+		}
+	}
+	return true
+}
+
+// crawledTargets повертає розібрані під час обходу сторінки в стабільному порядку
+func (c *Checker) crawledTargets() []*linkTarget {
+	c.linkMutex.Lock()
+	defer c.linkMutex.Unlock()
+
+	keys := make([]string, 0, len(c.linkTargets))
+	for key, target := range c.linkTargets {
+		if target.crawled {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	result := make([]*linkTarget, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, c.linkTargets[key])
+	}
+	return result
+}
+
+// inScope перевіряє, чи входить посилання зі сторінки pageURL в область обходу
+func (c *Checker) inScope(pageURL, linkURL string) bool {
+	link, err := url.Parse(linkURL)
+	if err != nil || (link.Scheme != "http" && link.Scheme != "https") {
+		return false
+	}
+
+	switch scope := c.cfg.CrawlScope; scope {
+	case ScopeHost, ScopeDomain:
+		page, err := url.Parse(pageURL)
+		if err != nil {
+			return false
+		}
+		host, linkHost := strings.ToLower(page.Hostname()), strings.ToLower(link.Hostname())
+		if scope == ScopeHost {
+			return linkHost == host
+		}
+		domain := strings.TrimPrefix(host, "www.")
+		return linkHost == domain || strings.HasSuffix(linkHost, "."+domain)
+	default:
+		// Префікс URL, наприклад https://example.com/blog/; посилання — оригінальні адреси
+		return strings.HasPrefix(linkURL, scope)
+	}
+}
+
+// reportCoverage порівнює sitemap зі сторінками, знайденими за посиланнями
+func (c *Checker) reportCoverage(report *Report) {
+	report.Coverage = &Coverage{Orphans: make([]string, 0), MissingFromSitemap: make([]MissingPage, 0)}
+	if !c.cfg.CheckLinks {
+		return
+	}
+
+	// Вхідні посилання за адресою; редірект зараховується і кінцевій адресі
+	inbound := make(map[string][]LinkSource)
+	for _, source := range c.crawledTargets() {
+		// Адреси сторінок, посилань і редіректів уже зведено до оригінальних URL
+		for _, link := range source.links {
+			key := link.URL
+			if key == source.url {
+				continue
+			}
+			keys := []string{key}
+			c.linkMutex.Lock()
+			if target, ok := c.linkTargets[key]; ok && len(target.redirects) > 0 {
+				keys = append(keys, target.redirects[len(target.redirects)-1])
+			}
+			c.linkMutex.Unlock()
+			for _, k := range keys {
+				inbound[k] = append(inbound[k], LinkSource{Page: source.url, Anchor: link.Anchor})
+			}
+		}
+	}
+
+	home := siteRoot(c.cfg.SitemapURL)
+	for i := range report.Pages {
+		page := &report.Pages[i]
+		if page.URL == home || len(inbound[page.URL]) > 0 {
+			continue
+		}
+		report.Coverage.Orphans = append(report.Coverage.Orphans, page.URL)
+		page.addFinding(FindingOrphanPage, SeverityWarning, "", "на сторінку немає внутрішніх посилань")
+	}
+	sort.Strings(report.Coverage.Orphans)
+
+	c.linkMutex.Lock()
+	for key, target := range c.linkTargets {
+		if target.inSitemap || !target.indexable || len(inbound[key]) == 0 {
+			continue
+		}
+		sources := inbound[key]
+		sort.SliceStable(sources, func(i, j int) bool { return sources[i].Page < sources[j].Page })
+		report.Coverage.MissingFromSitemap = append(report.Coverage.MissingFromSitemap, MissingPage{URL: target.url, Sources: sources})
+	}
+	c.linkMutex.Unlock()
+	sort.Slice(report.Coverage.MissingFromSitemap, func(i, j int) bool {
+		return report.Coverage.MissingFromSitemap[i].URL < report.Coverage.MissingFromSitemap[j].URL
+	})
+}
+
+// responseURL повертає оригінальну адресу відповіді на запит за originalURL:
+// без редіректів — саму originalURL, інакше кінцеву адресу, зведену правилами
+// переписування (Restore неоднозначний, тож застосовується лише до редіректів)
+func (c *Checker) responseURL(originalURL string, resp *fetcher.Response) string {
+	if len(resp.Redirects) == 0 {
+		return originalURL
+	}
+	return c.cfg.Rewrites.Restore(resp.URL)
+}
+
+// restoreURLs зводить адреси, отримані від переписаного сайту (редіректи), до оригінальних
+func (c *Checker) restoreURLs(urls []string) []string {
+	if c.cfg.Rewrites.Empty() || len(urls) == 0 {
+		return urls
+	}
+	restored := make([]string, len(urls))
+	for i, u := range urls {
+		restored[i] = c.cfg.Rewrites.Restore(u)
+	}
+	return restored
+}
+
+// siteRoot повертає адресу головної сторінки сайту
+func siteRoot(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	return (&url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/"}).String()
+}
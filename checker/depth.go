@@ -0,0 +1,143 @@
+package checker
+
+import (
+	"sort"
+	"strconv"
+)
+
+// FindingClickDepth — сторінка розташована надто глибоко від головної
+const FindingClickDepth = "click_depth_too_deep"
+
+// DeepPage — сторінка з sitemap, глибина якої перевищує поріг
+type DeepPage struct {
+	URL   string `json:"url"`
+	Depth int    `json:"depth"`
+}
+
+// SitemapDepth — розподіл глибини кліків для сторінок одного файлу sitemap
+type SitemapDepth struct {
+	Sitemap      string         `json:"sitemap"`
+	Distribution map[string]int `json:"distribution"` // Кількість сторінок за глибиною
+	Unreachable  int            `json:"unreachable"`  // Сторінки, недосяжні з головної
+	Unknown      int            `json:"unknown"`      // Сторінки з невідомою глибиною через обмеження обходу
+}
+
+// ClickDepthReport — глибина кліків сторінок sitemap від головної сторінки
+type ClickDepthReport struct {
+	Threshold   int            `json:"threshold"`
+	Deep        []DeepPage     `json:"deep"`
+	Unreachable []string       `json:"unreachable"`
+	Unknown     []string       `json:"unknown"` // Не знайдені з головної, але можуть бути за межею CRAWL_MAX_DEPTH
+	Sitemaps    []SitemapDepth `json:"sitemaps"`
+}
+
+// clickDepths обчислює мінімальну кількість кліків від головної сторінки
+// пошуком у ширину за графом посилань, зібраним під час обходу. Ключі — оригінальні
+// адреси. truncated — пошук дійшов до сторінок, посилання яких не розбиралися
+// (гранична глибина обходу або його переривання), тож сторінки поза результатом
+// можуть бути досяжними глибше
+func (c *Checker) clickDepths() (depths map[string]int, truncated bool) {
+	depths = make(map[string]int)
+	home := siteRoot(c.cfg.SitemapURL)
+	if home == "" {
+		return depths, false
+	}
+
+	c.linkMutex.Lock()
+	defer c.linkMutex.Unlock()
+
+	visit := func(key string, depth int, queue []string) []string {
+		if _, seen := depths[key]; seen {
+			return queue
+		}
+		depths[key] = depth
+		queue = append(queue, key)
+
+		// Кінцева адреса редіректу досяжна за ту саму кількість кліків
+		if target, ok := c.linkTargets[key]; ok && len(target.redirects) > 0 {
+			final := target.redirects[len(target.redirects)-1]
+			if _, seen := depths[final]; !seen {
+				depths[final] = depth
+				queue = append(queue, final)
+			}
+		}
+		return queue
+	}
+
+	queue := visit(home, 0, nil)
+	for len(queue) > 0 {
+		key := queue[0]
+		queue = queue[1:]
+
+		target, ok := c.linkTargets[key]
+		if !ok || target.unexpanded {
+			truncated = true
+			continue
+		}
+		for _, link := range target.links {
+			queue = visit(link.URL, depths[key]+1, queue)
+		}
+	}
+
+	return depths, truncated
+}
+
+// reportClickDepth записує глибину кліків сторінок sitemap та її розподіл за файлами sitemap
+func (c *Checker) reportClickDepth(report *Report) {
+	report.ClickDepth = &ClickDepthReport{
+		Threshold:   c.cfg.ClickDepthThreshold,
+		Deep:        make([]DeepPage, 0),
+		Unreachable: make([]string, 0),
+		Unknown:     make([]string, 0),
+		Sitemaps:    make([]SitemapDepth, 0),
+	}
+	if !c.cfg.CheckLinks {
+		return
+	}
+
+	depths, truncated := c.clickDepths()
+	sitemaps := make(map[string]*SitemapDepth)
+	var order []string
+
+	for i := range report.Pages {
+		page := &report.Pages[i]
+
+		stats, ok := sitemaps[page.Sitemap]
+		if !ok {
+			stats = &SitemapDepth{Sitemap: page.Sitemap, Distribution: make(map[string]int)}
+			sitemaps[page.Sitemap] = stats
+			order = append(order, page.Sitemap)
+		}
+
+		depth, reachable := depths[page.URL]
+		switch {
+		case !reachable && truncated:
+			// Обхід від сторінок sitemap міг не дійти до шляху від головної
+			stats.Unknown++
+			report.ClickDepth.Unknown = append(report.ClickDepth.Unknown, page.URL)
+			continue
+		case !reachable:
+			stats.Unreachable++
+			report.ClickDepth.Unreachable = append(report.ClickDepth.Unreachable, page.URL)
+			continue
+		}
+
+		page.ClickDepth = &depth
+		stats.Distribution[strconv.Itoa(depth)]++
+		if depth > c.cfg.ClickDepthThreshold {
+			report.ClickDepth.Deep = append(report.ClickDepth.Deep, DeepPage{URL: page.URL, Depth: depth})
+			page.addFinding(FindingClickDepth, SeverityWarning, "", "сторінка на глибині %d кліків від головної (поріг %d)", depth, c.cfg.ClickDepthThreshold)
+		}
+	}
+
+	sort.Slice(report.ClickDepth.Deep, func(i, j int) bool {
+		a, b := report.ClickDepth.Deep[i], report.ClickDepth.Deep[j]
+		return a.Depth > b.Depth || a.Depth == b.Depth && a.URL < b.URL
+	})
+	sort.Strings(report.ClickDepth.Unreachable)
+	sort.Strings(report.ClickDepth.Unknown)
+	sort.Strings(order)
+	for _, sitemap := range order {
+		report.ClickDepth.Sitemaps = append(report.ClickDepth.Sitemaps, *sitemaps[sitemap])
+	}
+}
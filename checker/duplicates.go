@@ -0,0 +1,206 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"sitemap-checker/simhash"
+)
+
+// Типи знахідок щодо дублів вмісту
+const (
+	FindingContentDuplicate     = "content_duplicate"
+	FindingContentNearDuplicate = "content_near_duplicate"
+)
+
+// NearDuplicateCluster — група сторінок з майже однаковим основним вмістом
+type NearDuplicateCluster struct {
+	URLs          []string            `json:"urls"`
+	MinSimilarity float64             `json:"min_similarity"` // Найменша схожість серед пар кластера
+	Pairs         []NearDuplicatePair `json:"pairs"`
+}
+
+// NearDuplicatePair — пара сторінок, схожість яких перевищує поріг
+type NearDuplicatePair struct {
+	A          string  `json:"a"`
+	B          string  `json:"b"`
+	Similarity float64 `json:"similarity"`
+}
+
+// checkContent обчислює відбиток SimHash основного тексту сторінки
+func (c *Checker) checkContent(ctx context.Context, page *Page, result *PageResult) {
+	words := simhash.Words(page.Document.MainText())
+	result.WordCount = len(words)
+	if len(words) == 0 {
+		return
+	}
+
+	result.fingerprint = simhash.Fingerprint(words)
+	result.SimHash = fmt.Sprintf("%016x", result.fingerprint)
+}
+
+// reportDuplicates групує сторінки з однаковим нормалізованим вмістом
+func (c *Checker) reportDuplicates(report *Report) {
+	report.Duplicates = make([]DuplicateGroup, 0)
+
+	groups := make(map[string][]int)
+	var hashes []string
+	for i, page := range report.Pages {
+		if page.StatusCode != http.StatusOK || len(page.Redirects) > 0 || page.ContentHash == "" {
+			continue
+		}
+		if _, exists := groups[page.ContentHash]; !exists {
+			hashes = append(hashes, page.ContentHash)
+		}
+		groups[page.ContentHash] = append(groups[page.ContentHash], i)
+	}
+
+	for _, hash := range hashes {
+		indexes := groups[hash]
+		if len(indexes) < 2 {
+			continue
+		}
+		group := DuplicateGroup{Value: hash}
+		for _, i := range indexes {
+			group.URLs = append(group.URLs, report.Pages[i].URL)
+			report.Pages[i].addFinding(FindingContentDuplicate, SeverityWarning, "", "вміст збігається з іншими сторінками: %d", len(indexes)-1)
+		}
+		sort.Strings(group.URLs)
+		report.Duplicates = append(report.Duplicates, group)
+	}
+
+	sort.SliceStable(report.Duplicates, func(i, j int) bool {
+		return len(report.Duplicates[i].URLs) > len(report.Duplicates[j].URLs)
+	})
+}
+
+// reportNearDuplicates групує сторінки, схожість яких не менша за поріг
+func (c *Checker) reportNearDuplicates(report *Report) {
+	report.NearDuplicates = make([]NearDuplicateCluster, 0)
+
+	// Короткі тексти дають ненадійні відбитки, тому не порівнюються
+	var candidates []int
+	for i, page := range report.Pages {
+		if page.StatusCode == http.StatusOK && len(page.Redirects) == 0 && page.SimHash != "" && page.WordCount >= c.cfg.NearDuplicateMinWords {
+			candidates = append(candidates, i)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		return report.Pages[candidates[i]].URL < report.Pages[candidates[j]].URL
+	})
+
+	// Точні дублі вже зібрані в duplicates: від кожної групи порівнюється лише
+	// перша за адресою сторінка, щоб кластери не повторювали ці групи
+	hashes := make(map[string]bool)
+	unique := candidates[:0]
+	for _, i := range candidates {
+		if hash := report.Pages[i].ContentHash; hash != "" {
+			if hashes[hash] {
+				continue
+			}
+			hashes[hash] = true
+		}
+		unique = append(unique, i)
+	}
+	candidates = unique
+
+	// Об'єднуємо схожі пари в кластери (система неперетинних множин)
+	parent := make(map[int]int, len(candidates))
+	var find func(int) int
+	find = func(i int) int {
+		if p, ok := parent[i]; ok && p != i {
+			parent[i] = find(p)
+			return parent[i]
+		}
+		return i
+	}
+
+	type edge struct {
+		a, b       int
+		similarity float64
+	}
+	var edges []edge
+	for _, pair := range nearDuplicateCandidates(report.Pages, candidates, c.cfg.NearDuplicateThreshold) {
+		a, b := candidates[pair[0]], candidates[pair[1]]
+		similarity := simhash.Similarity(report.Pages[a].fingerprint, report.Pages[b].fingerprint)
+		if similarity < c.cfg.NearDuplicateThreshold {
+			continue
+		}
+		edges = append(edges, edge{a: a, b: b, similarity: similarity})
+		if ra, rb := find(a), find(b); ra != rb {
+			parent[rb] = ra
+		}
+	}
+
+	// Розподіляємо пари за кластерами
+	clusters := make(map[int]*NearDuplicateCluster)
+	members := make(map[int]map[int]bool)
+	var roots []int
+	for _, e := range edges {
+		root := find(e.a)
+		cluster, ok := clusters[root]
+		if !ok {
+			cluster = &NearDuplicateCluster{MinSimilarity: 1}
+			clusters[root] = cluster
+			members[root] = make(map[int]bool)
+			roots = append(roots, root)
+		}
+		cluster.Pairs = append(cluster.Pairs, NearDuplicatePair{A: report.Pages[e.a].URL, B: report.Pages[e.b].URL, Similarity: e.similarity})
+		if e.similarity < cluster.MinSimilarity {
+			cluster.MinSimilarity = e.similarity
+		}
+		members[root][e.a] = true
+		members[root][e.b] = true
+	}
+
+	for _, root := range roots {
+		cluster := clusters[root]
+		for i := range members[root] {
+			cluster.URLs = append(cluster.URLs, report.Pages[i].URL)
+			report.Pages[i].addFinding(FindingContentNearDuplicate, SeverityWarning, "", "вміст майже збігається з іншими сторінками: %d (схожість від %.2f)", len(members[root])-1, cluster.MinSimilarity)
+		}
+		sort.Strings(cluster.URLs)
+		report.NearDuplicates = append(report.NearDuplicates, *cluster)
+	}
+
+	sort.SliceStable(report.NearDuplicates, func(i, j int) bool {
+		return len(report.NearDuplicates[i].URLs) > len(report.NearDuplicates[j].URLs)
+	})
+}
+
+// nearDuplicateCandidates повертає пари позицій у candidates (x < y), відбитки яких
+// мають спільний блок. Відбиток ділиться на MaxDistance+1 блоків, тож пари зі
+// схожістю від threshold не пропускаються, а попарно порівнюються лише сторінки
+// з однаковим блоком замість усіх n² пар
+func nearDuplicateCandidates(pages []PageResult, candidates []int, threshold float64) [][2]int {
+	bandCount := simhash.MaxDistance(threshold) + 1
+
+	buckets := make(map[simhash.Band][]int)
+	for x, i := range candidates {
+		for _, band := range simhash.Bands(pages[i].fingerprint, bandCount) {
+			buckets[band] = append(buckets[band], x)
+		}
+	}
+
+	seen := make(map[[2]int]bool)
+	var pairs [][2]int
+	for _, bucket := range buckets {
+		for i := 0; i < len(bucket); i++ {
+			for j := i + 1; j < len(bucket); j++ {
+				pair := [2]int{bucket[i], bucket[j]}
+				if !seen[pair] {
+					seen[pair] = true
+					pairs = append(pairs, pair)
+				}
+			}
+		}
+	}
+
+	// Стабільний порядок пар — за адресами сторінок, як у candidates
+	sort.Slice(pairs, func(i, j int) bool {
+		return pairs[i][0] < pairs[j][0] || pairs[i][0] == pairs[j][0] && pairs[i][1] < pairs[j][1]
+	})
+	return pairs
+}
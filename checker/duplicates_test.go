@@ -0,0 +1,142 @@
+package checker
+
+import (
+	"math/rand"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"sitemap-checker/simhash"
+)
+
+// TestNearDuplicateCandidates перевіряє, що пошук за блоками знаходить усі пари,
+// які знайшло б попарне порівняння
+func TestNearDuplicateCandidates(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	// Групи схожих відбитків: базовий відбиток і копії зі зміненими бітами
+	var pages []PageResult
+	for group := 0; group < 20; group++ {
+		base := rng.Uint64()
+		for copies := 0; copies < 5; copies++ {
+			fingerprint := base
+			for flips := rng.Intn(10); flips > 0; flips-- {
+				fingerprint ^= 1 << uint(rng.Intn(64))
+			}
+			pages = append(pages, PageResult{fingerprint: fingerprint})
+		}
+	}
+	candidates := make([]int, len(pages))
+	for i := range candidates {
+		candidates[i] = i
+	}
+
+	for _, threshold := range []float64{1, 0.95, 0.9, 0.85, 0.75} {
+		var want [][2]int
+		for x := 0; x < len(candidates); x++ {
+			for y := x + 1; y < len(candidates); y++ {
+				if simhash.Similarity(pages[x].fingerprint, pages[y].fingerprint) >= threshold {
+					want = append(want, [2]int{x, y})
+				}
+			}
+		}
+
+		var got [][2]int
+		pairs := nearDuplicateCandidates(pages, candidates, threshold)
+		for _, pair := range pairs {
+			if simhash.Similarity(pages[pair[0]].fingerprint, pages[pair[1]].fingerprint) >= threshold {
+				got = append(got, pair)
+			}
+		}
+
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("поріг %.2f: знайдено %d схожих пар, попарне порівняння — %d", threshold, len(got), len(want))
+		}
+		if total := len(pages) * (len(pages) - 1) / 2; len(pairs) >= total {
+			t.Errorf("поріг %.2f: порівнюється %d пар з %d, блоки не зменшують кількість порівнянь", threshold, len(pairs), total)
+		}
+	}
+}
+
+// TestReportDuplicatesDocuments перевіряє, що однакові документи, які не є HTML,
+// позначаються як точні дублі
+func TestReportDuplicatesDocuments(t *testing.T) {
+	pdf := http.Header{"Content-Type": {"application/pdf"}}
+	f := &fakeFetcher{responses: map[string]fakeResponse{
+		"https://example.com/sitemap.xml": {
+			header: http.Header{"Content-Type": {"application/xml"}},
+			body: `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+<url><loc>https://example.com/a.pdf</loc></url>
+<url><loc>https://example.com/b.pdf</loc></url>
+<url><loc>https://example.com/c.pdf</loc></url>
+<url><loc>https://example.com/empty.pdf</loc></url>
+<url><loc>https://example.com/empty2.pdf</loc></url>
+</urlset>`,
+		},
+		"https://example.com/a.pdf":      {header: pdf, body: "%PDF-1.4 прайс"},
+		"https://example.com/b.pdf":      {header: pdf, body: "%PDF-1.4 прайс"},
+		"https://example.com/c.pdf":      {header: pdf, body: "%PDF-1.4 каталог"},
+		"https://example.com/empty.pdf":  {header: pdf},
+		"https://example.com/empty2.pdf": {header: pdf},
+	}}
+	report := runChecker(t, testConfig(t, nil), f)
+
+	if len(report.Duplicates) != 1 || !reflect.DeepEqual(report.Duplicates[0].URLs, []string{"https://example.com/a.pdf", "https://example.com/b.pdf"}) {
+		t.Errorf("duplicates = %+v, очікувалась група a.pdf і b.pdf", report.Duplicates)
+	}
+	if !hasFinding(findPage(t, report, "https://example.com/a.pdf"), FindingContentDuplicate) {
+		t.Errorf("a.pdf без знахідки content_duplicate")
+	}
+}
+
+// TestReportNearDuplicatesExact перевіряє, що точні дублі не утворюють кластерів
+// майже однакових сторінок і представлені в них однією сторінкою
+func TestReportNearDuplicatesExact(t *testing.T) {
+	page := func(url, hash string, fingerprint uint64) PageResult {
+		return PageResult{URL: url, StatusCode: 200, ContentHash: hash, SimHash: "set", WordCount: 1000, fingerprint: fingerprint}
+	}
+	const base = 0x0123456789abcdef
+
+	tests := []struct {
+		name  string
+		pages []PageResult
+		want  [][]string
+	}{
+		{
+			"лише точні дублі",
+			[]PageResult{page("https://example.com/b/", "h1", base), page("https://example.com/a/", "h1", base)},
+			nil,
+		},
+		{
+			"точні дублі та майже дубль",
+			[]PageResult{page("https://example.com/b/", "h1", base), page("https://example.com/a/", "h1", base), page("https://example.com/c/", "h2", base^1)},
+			[][]string{{"https://example.com/a/", "https://example.com/c/"}},
+		},
+		{
+			"різні сторінки",
+			[]PageResult{page("https://example.com/a/", "h1", base), page("https://example.com/c/", "h2", ^uint64(base))},
+			nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := &Report{Pages: tt.pages}
+			(&Checker{cfg: testConfig(t, nil)}).reportNearDuplicates(report)
+
+			var got [][]string
+			for _, cluster := range report.NearDuplicates {
+				got = append(got, cluster.URLs)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("near_duplicates = %v, очікувалось %v", got, tt.want)
+			}
+			for _, p := range report.Pages {
+				if p.URL == "https://example.com/b/" && hasFinding(&p, FindingContentNearDuplicate) {
+					t.Errorf("точний дубль b/ позначено як майже дубль")
+				}
+			}
+		})
+	}
+}
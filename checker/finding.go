@@ -0,0 +1,28 @@
+package checker
+
+import "fmt"
+
+// Рівні важливості знахідок
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// Finding — проблема, виявлена під час перевірки сторінки
+type Finding struct {
+	Type     string `json:"type"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	URL      string `json:"url,omitempty"` // Пов'язаний URL (наприклад, канонічний)
+}
+
+// addFinding додає знахідку до результату сторінки
+func (r *PageResult) addFinding(findingType, severity, relatedURL, format string, args ...interface{}) {
+	r.Findings = append(r.Findings, Finding{
+		Type:     findingType,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+		URL:      relatedURL,
+	})
+}
@@ -0,0 +1,110 @@
+package checker
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"sitemap-checker/linkgraph"
+	"sitemap-checker/logger"
+)
+
+// linkGraph будує граф внутрішніх посилань за результатами обходу; вузли
+// ідентифікуються оригінальними адресами, як і записи обходу
+func (c *Checker) linkGraph() *linkgraph.Graph {
+	depths, _ := c.clickDepths()
+
+	c.linkMutex.Lock()
+	defer c.linkMutex.Unlock()
+
+	keys := make([]string, 0, len(c.linkTargets))
+	for key := range c.linkTargets {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	g := &linkgraph.Graph{}
+	index := make(map[string]int, len(keys))
+	addNode := func(key string) int {
+		if i, ok := index[key]; ok {
+			return i
+		}
+		node := linkgraph.Node{URL: key}
+		if target, ok := c.linkTargets[key]; ok {
+			node.StatusCode = target.statusCode
+			node.Indexable = target.indexable
+			node.InSitemap = target.inSitemap
+		}
+		if depth, ok := depths[key]; ok {
+			node.Depth = &depth
+		}
+		index[key] = len(g.Nodes)
+		g.Nodes = append(g.Nodes, node)
+		return index[key]
+	}
+
+	for _, key := range keys {
+		addNode(key)
+	}
+
+	// Повторні посилання між тими самими сторінками об'єднуються в одне ребро;
+	// ребро вважається nofollow, лише якщо всі такі посилання nofollow
+	edges := make(map[[2]int]int)
+	for _, key := range keys {
+		target := c.linkTargets[key]
+		from := index[key]
+		for _, link := range target.links {
+			to := addNode(link.URL)
+			if i, ok := edges[[2]int{from, to}]; ok {
+				g.Edges[i].Nofollow = g.Edges[i].Nofollow && link.Nofollow
+				continue
+			}
+			edges[[2]int{from, to}] = len(g.Edges)
+			g.Edges = append(g.Edges, linkgraph.Edge{From: from, To: to, Anchor: link.Anchor, Nofollow: link.Nofollow})
+		}
+	}
+
+	g.ComputePageRank()
+	return g
+}
+
+// reportPageRank записує внутрішній PageRank сторінок sitemap
+func (c *Checker) reportPageRank(report *Report) {
+	if !c.cfg.CheckLinks {
+		return
+	}
+
+	ranks := make(map[string]float64)
+	for _, node := range c.linkGraph().Nodes {
+		ranks[node.URL] = node.PageRank
+	}
+	for i := range report.Pages {
+		page := &report.Pages[i]
+		page.PageRank = ranks[page.URL]
+	}
+}
+
+// SaveLinkGraph зберігає граф посилань у файли prefix.<формат>
+func (c *Checker) SaveLinkGraph(prefix string, formats []string) error {
+	if len(formats) == 0 {
+		return nil
+	}
+
+	g := c.linkGraph()
+	for _, format := range formats {
+		filename := prefix + "." + format
+		file, err := os.Create(filename)
+		if err != nil {
+			return fmt.Errorf("помилка при створенні файлу: %v", err)
+		}
+		if err := g.Write(file, format); err != nil {
+			file.Close()
+			return fmt.Errorf("помилка при записі графа %s: %v", filename, err)
+		}
+		if err := file.Close(); err != nil {
+			logger.Error("помилка при закритті файлу: %v", err)
+		}
+	}
+
+	return nil
+}
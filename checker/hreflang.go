@@ -0,0 +1,109 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Типи знахідок щодо альтернативних мовних версій (hreflang)
+const (
+	FindingHreflangInvalid     = "hreflang_invalid"
+	FindingHreflangConflict    = "hreflang_conflict"
+	FindingHreflangSelfMissing = "hreflang_self_missing"
+	FindingHreflangNoReturn    = "hreflang_no_return"
+)
+
+// hreflangPattern — код мови ISO 639 з необов'язковими письмом (ISO 15924) та регіоном
+// (ISO 3166-1 або UN M.49)
+var hreflangPattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z]{4})?(-([a-z]{2}|[0-9]{3}))?$`)
+
+// HreflangLink — альтернативна мовна версія сторінки
+type HreflangLink struct {
+	Lang string `json:"lang"`
+	URL  string `json:"url"`
+}
+
+// checkHreflang збирає <link rel="alternate" hreflang> з <head>; адреси
+// розв'язуються відносно оригінальної адреси сторінки, тож порівнюються з loc
+// без урахування правил переписування
+func (c *Checker) checkHreflang(ctx context.Context, page *Page, result *PageResult) {
+	if page.Response.StatusCode != http.StatusOK {
+		return
+	}
+
+	base := documentBase(page.FinalURL, page.Document)
+	byLang := make(map[string]string)
+	self := false
+	for _, el := range page.Document.HeadLinks("alternate") {
+		lang, ok := el.LookupAttr("hreflang")
+		if !ok {
+			continue
+		}
+		lang = strings.ToLower(strings.TrimSpace(lang))
+		raw := strings.TrimSpace(el.Attr("href"))
+		resolved := resolveURL(base, raw)
+
+		switch {
+		case lang != "x-default" && !hreflangPattern.MatchString(lang):
+			result.addFinding(FindingHreflangInvalid, SeverityError, resolved, "некоректний код мови hreflang: %q", lang)
+			continue
+		case resolved == "":
+			result.addFinding(FindingHreflangInvalid, SeverityError, "", "некоректна адреса hreflang=%q: %q", lang, raw)
+			continue
+		}
+
+		if previous, exists := byLang[lang]; exists {
+			if previous != resolved {
+				result.addFinding(FindingHreflangConflict, SeverityError, resolved, "hreflang=%q вказує на різні адреси: %s, %s", lang, previous, resolved)
+			}
+			continue
+		}
+		byLang[lang] = resolved
+		result.Hreflang = append(result.Hreflang, HreflangLink{Lang: lang, URL: resolved})
+		if c.cfg.Rewrites.Equal(resolved, page.Entry.Loc) {
+			self = true
+		}
+	}
+
+	if len(result.Hreflang) > 0 && !self {
+		result.addFinding(FindingHreflangSelfMissing, SeverityWarning, "", "серед альтернативних версій hreflang немає самої сторінки")
+	}
+}
+
+// reportHreflang перевіряє зворотні посилання hreflang: альтернативна версія з
+// sitemap має посилатися на сторінку у відповідь, інакше пошукові системи ігнорують пару
+func (c *Checker) reportHreflang(report *Report) {
+	pageIndex := make(map[string]int, len(report.Pages))
+	for i, page := range report.Pages {
+		pageIndex[page.URL] = i
+	}
+
+	for i := range report.Pages {
+		page := &report.Pages[i]
+		var missing []string
+		for _, alternate := range page.Hreflang {
+			j, inSitemap := pageIndex[alternate.URL]
+			if !inSitemap || alternate.URL == page.URL {
+				continue
+			}
+			returns := false
+			for _, back := range report.Pages[j].Hreflang {
+				if back.URL == page.URL {
+					returns = true
+					break
+				}
+			}
+			if !returns && !containsString(missing, alternate.URL) {
+				missing = append(missing, alternate.URL)
+			}
+		}
+
+		sort.Strings(missing)
+		for _, u := range missing {
+			page.addFinding(FindingHreflangNoReturn, SeverityError, u, "альтернативна версія %s не посилається на сторінку через hreflang", u)
+		}
+	}
+}
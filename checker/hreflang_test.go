@@ -0,0 +1,111 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"sitemap-checker/fetcher"
+	"sitemap-checker/htmldoc"
+	"sitemap-checker/parser"
+)
+
+func TestCheckHreflang(t *testing.T) {
+	tests := []struct {
+		name     string
+		head     string
+		findings map[string]bool
+		links    int
+	}{
+		{
+			"коректний набір",
+			`<link rel="alternate" hreflang="uk" href="/uk/"><link rel="alternate" hreflang="en-GB" href="https://example.com/en/"><link rel="alternate" hreflang="x-default" href="/en/">`,
+			map[string]bool{FindingHreflangInvalid: false, FindingHreflangSelfMissing: false, FindingHreflangConflict: false}, 3,
+		},
+		{
+			"некоректний код мови",
+			`<link rel="alternate" hreflang="uk" href="/uk/"><link rel="alternate" hreflang="english" href="/en/">`,
+			map[string]bool{FindingHreflangInvalid: true}, 1,
+		},
+		{
+			"регіон UN M.49 та письмо",
+			`<link rel="alternate" hreflang="uk" href="/uk/"><link rel="alternate" hreflang="es-419" href="/es/"><link rel="alternate" hreflang="zh-Hant-TW" href="/tw/">`,
+			map[string]bool{FindingHreflangInvalid: false}, 3,
+		},
+		{
+			"без самої сторінки",
+			`<link rel="alternate" hreflang="en" href="/en/">`,
+			map[string]bool{FindingHreflangSelfMissing: true}, 1,
+		},
+		{
+			"одна мова — різні адреси",
+			`<link rel="alternate" hreflang="uk" href="/uk/"><link rel="alternate" hreflang="en" href="/en/"><link rel="alternate" hreflang="EN" href="/en-2/">`,
+			map[string]bool{FindingHreflangConflict: true}, 2,
+		},
+		{"без hreflang", `<link rel="alternate" type="application/rss+xml" href="/feed/">`, map[string]bool{FindingHreflangSelfMissing: false}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig(t, map[string]string{"REWRITE_HOSTS": "example.com=staging.local"})
+			page := &Page{
+				Entry:    parser.URL{Loc: "https://example.com/uk/"},
+				FinalURL: "https://example.com/uk/",
+				Response: &fetcher.Response{URL: "https://staging.local/uk/", StatusCode: http.StatusOK},
+				Document: htmldoc.Parse([]byte("<html><head>" + tt.head + "</head><body></body></html>")),
+			}
+			result := &PageResult{}
+			(&Checker{cfg: cfg}).checkHreflang(context.Background(), page, result)
+			for findingType, want := range tt.findings {
+				if got := hasFinding(result, findingType); got != want {
+					t.Errorf("знахідка %s = %v, очікувалось %v", findingType, got, want)
+				}
+			}
+			if len(result.Hreflang) != tt.links {
+				t.Errorf("hreflang = %+v, очікувалось %d посилань", result.Hreflang, tt.links)
+			}
+		})
+	}
+}
+
+// TestCheckerHreflangRewrite перевіряє, що з REWRITE_HOSTS альтернативні версії
+// порівнюються в оригінальних адресах і не позначаються як невідповідні
+func TestCheckerHreflangRewrite(t *testing.T) {
+	alternates := `<link rel="alternate" hreflang="uk" href="https://example.com/uk/"><link rel="alternate" hreflang="en" href="https://example.com/en/">`
+	f := &fakeFetcher{responses: map[string]fakeResponse{
+		"https://staging.local/sitemap.xml": {
+			header: http.Header{"Content-Type": {"application/xml"}},
+			body: `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">
+<url><loc>https://example.com/uk/</loc></url>
+<url><loc>https://example.com/en/</loc></url>
+<url><loc>https://example.com/de/</loc></url>
+</urlset>`,
+		},
+		"https://staging.local/uk/": {body: `<html><head><title>Українська версія</title>` + alternates + `<link rel="alternate" hreflang="de" href="/de/"></head><body></body></html>`},
+		"https://staging.local/en/": {body: `<html><head><title>English version</title>` + alternates + `</head><body></body></html>`},
+		"https://staging.local/de/": {body: `<html><head><title>Deutsche Version</title><link rel="alternate" hreflang="de" href="/de/"></head><body></body></html>`},
+	}}
+	report := runChecker(t, testConfig(t, map[string]string{"REWRITE_HOSTS": "example.com=staging.local"}), f)
+
+	uk := findPage(t, report, "https://example.com/uk/")
+	en := findPage(t, report, "https://example.com/en/")
+	for _, page := range []*PageResult{uk, en} {
+		if hasFinding(page, FindingHreflangSelfMissing) {
+			t.Errorf("%s: сторінку не знайдено серед власних hreflang", page.URL)
+		}
+	}
+	if hasFinding(en, FindingHreflangNoReturn) {
+		t.Errorf("en: зворотне посилання з uk не враховано")
+	}
+
+	var noReturn []string
+	for _, finding := range uk.Findings {
+		if finding.Type == FindingHreflangNoReturn {
+			noReturn = append(noReturn, finding.URL)
+		}
+	}
+	if len(noReturn) != 1 || noReturn[0] != "https://example.com/de/" {
+		t.Errorf("uk: hreflang_no_return = %v, очікувалось [https://example.com/de/]", noReturn)
+	}
+}
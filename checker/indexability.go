@@ -0,0 +1,216 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"sitemap-checker/htmldoc"
+	"sitemap-checker/logger"
+	"sitemap-checker/robots"
+)
+
+// Причини, з яких сторінка не індексується
+const (
+	ReasonStatus        = "status"
+	ReasonRobotsTxt     = "robots_txt"
+	ReasonNoindex       = "noindex"
+	ReasonCanonicalized = "canonicalized"
+	ReasonRedirect      = "redirect"
+)
+
+// knownRobotsDirectives — директиви meta robots / X-Robots-Tag; усе інше
+// перед двокрапкою в X-Robots-Tag вважається назвою бота
+var knownRobotsDirectives = map[string]bool{
+	"all": true, "noindex": true, "nofollow": true, "none": true,
+	"noarchive": true, "nosnippet": true, "indexifembedded": true,
+	"notranslate": true, "noimageindex": true, "unavailable_after": true,
+	"max-snippet": true, "max-image-preview": true, "max-video-preview": true,
+	"nocache": true, "index": true, "follow": true,
+}
+
+// RobotsDirective — директиви індексації з одного джерела
+type RobotsDirective struct {
+	Source     string   `json:"source"` // meta або header
+	Bot        string   `json:"bot"`    // robots — для всіх ботів, інакше токен бота
+	Directives []string `json:"directives"`
+}
+
+// IndexabilityReason — причина, з якої сторінка не індексується
+type IndexabilityReason struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// Indexability — підсумковий вердикт щодо індексації сторінки
+type Indexability struct {
+	Indexable bool                 `json:"indexable"`
+	Reasons   []IndexabilityReason `json:"reasons,omitempty"`
+}
+
+// NonIndexablePage — сторінка з sitemap, яка не індексується
+type NonIndexablePage struct {
+	URL     string               `json:"url"`
+	Reasons []IndexabilityReason `json:"reasons"`
+}
+
+// checkIndexability збирає директиви індексації та обчислює вердикт для сторінки
+func (c *Checker) checkIndexability(ctx context.Context, page *Page, result *PageResult) {
+	result.RobotsDirectives = c.robotsDirectives(page.Response.Header, page.Document)
+
+	verdict := Indexability{Indexable: true}
+	addReason := func(code, format string, args ...interface{}) {
+		verdict.Indexable = false
+		verdict.Reasons = append(verdict.Reasons, IndexabilityReason{Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if result.StatusCode != http.StatusOK {
+		addReason(ReasonStatus, "статус відповіді %d", result.StatusCode)
+	}
+	if result.IsBlockedByRobotsTxt {
+		addReason(ReasonRobotsTxt, "сторінка заблокована в robots.txt")
+	}
+	if d := noindexDirective(result.RobotsDirectives); d != nil {
+		addReason(ReasonNoindex, "noindex у %s (%s)", d.Source, d.Bot)
+	}
+	if result.CanonicalURL != "" && !c.cfg.Rewrites.Equal(result.CanonicalURL, page.Entry.Loc) {
+		addReason(ReasonCanonicalized, "канонічна сторінка — %s", result.CanonicalURL)
+	}
+	if len(result.Redirects) > 0 {
+		addReason(ReasonRedirect, "перенаправлення на %s", result.Redirects[len(result.Redirects)-1])
+	}
+
+	if !verdict.Indexable {
+		logger.Error("сторінка з sitemap не індексується: %s (%s)", page.Entry.Loc, verdict.Reasons[0].Message)
+	}
+	result.Indexability = verdict
+}
+
+// robotsDirectives збирає директиви з <meta name="robots">, мета-тегів окремих
+// ботів та заголовка X-Robots-Tag (зокрема у формі "googlebot: noindex")
+func (c *Checker) robotsDirectives(header http.Header, doc *htmldoc.Document) []RobotsDirective {
+	bots := append([]string{"robots", robots.ProductToken(c.cfg.UserAgent)}, c.cfg.IndexingBots...)
+
+	var result []RobotsDirective
+	for _, bot := range uniqueStrings(bots) {
+		for _, content := range doc.Meta(bot) {
+			if directives := splitDirectives(content); len(directives) > 0 {
+				result = append(result, RobotsDirective{Source: "meta", Bot: bot, Directives: directives})
+			}
+		}
+	}
+
+	for _, value := range header.Values("X-Robots-Tag") {
+		for _, d := range parseXRobotsTag(value) {
+			if d.Bot == "robots" || containsString(bots, d.Bot) {
+				result = append(result, d)
+			}
+		}
+	}
+
+	return result
+}
+
+// parseXRobotsTag розбирає значення X-Robots-Tag; префікс "бот:" стосується
+// всіх наступних директив до наступного префікса
+func parseXRobotsTag(value string) []RobotsDirective {
+	var result []RobotsDirective
+	current := RobotsDirective{Source: "header", Bot: "robots"}
+
+	for _, part := range splitRobotsList(value) {
+		if name, rest, ok := strings.Cut(part, ":"); ok && !knownRobotsDirectives[strings.ToLower(strings.TrimSpace(name))] {
+			if len(current.Directives) > 0 {
+				result = append(result, current)
+			}
+			current = RobotsDirective{Source: "header", Bot: strings.ToLower(strings.TrimSpace(name))}
+			part = strings.TrimSpace(rest)
+			if part == "" {
+				continue
+			}
+		}
+		current.Directives = append(current.Directives, strings.ToLower(part))
+	}
+
+	if len(current.Directives) > 0 {
+		result = append(result, current)
+	}
+	return result
+}
+
+// splitDirectives розбирає список директив через кому
+func splitDirectives(content string) []string {
+	var directives []string
+	for _, d := range splitRobotsList(content) {
+		directives = append(directives, strings.ToLower(d))
+	}
+	return directives
+}
+
+// splitRobotsList ділить список директив за комами, які починають нову директиву
+// або префікс "бот:"; решта ком належить значенню попередньої директиви, як у
+// "unavailable_after: Sunday, 01-Jan-2027 00:00:00 GMT"
+func splitRobotsList(value string) []string {
+	var parts []string
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if len(parts) > 0 && !startsDirective(part) {
+			parts[len(parts)-1] += ", " + part
+			continue
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+// startsDirective повідомляє, чи починається частина списку з відомої директиви
+// або з префікса "бот:"
+func startsDirective(part string) bool {
+	name := strings.ToLower(part)
+	end := strings.IndexFunc(name, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_')
+	})
+	if end < 0 {
+		return knownRobotsDirectives[name]
+	}
+	if knownRobotsDirectives[name[:end]] {
+		return true
+	}
+	return end > 0 && strings.HasPrefix(strings.TrimLeft(name[end:], " "), ":")
+}
+
+// noindexDirective повертає перше джерело з noindex або none
+func noindexDirective(directives []RobotsDirective) *RobotsDirective {
+	for i, d := range directives {
+		for _, directive := range d.Directives {
+			if directive == "noindex" || directive == "none" {
+				return &directives[i]
+			}
+		}
+	}
+	return nil
+}
+
+// reportNonIndexable перелічує сторінки з sitemap, які не індексуються
+func (c *Checker) reportNonIndexable(report *Report) {
+	report.NonIndexable = make([]NonIndexablePage, 0)
+	for _, page := range report.Pages {
+		if !page.Indexability.Indexable {
+			report.NonIndexable = append(report.NonIndexable, NonIndexablePage{URL: page.URL, Reasons: page.Indexability.Reasons})
+		}
+	}
+}
+
+// uniqueStrings повертає список без повторів і порожніх значень
+func uniqueStrings(list []string) []string {
+	var result []string
+	for _, s := range list {
+		if s != "" && !containsString(result, s) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"sitemap-checker/fetcher"
+	"sitemap-checker/htmldoc"
+	"sitemap-checker/parser"
+)
+
+func TestParseXRobotsTag(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []RobotsDirective
+	}{
+		{"noindex, nofollow", []RobotsDirective{{Source: "header", Bot: "robots", Directives: []string{"noindex", "nofollow"}}}},
+		{"NoIndex,,  ", []RobotsDirective{{Source: "header", Bot: "robots", Directives: []string{"noindex"}}}},
+		{"", nil},
+		{
+			"googlebot: noindex, nofollow, otherbot: noarchive",
+			[]RobotsDirective{
+				{Source: "header", Bot: "googlebot", Directives: []string{"noindex", "nofollow"}},
+				{Source: "header", Bot: "otherbot", Directives: []string{"noarchive"}},
+			},
+		},
+		{"max-snippet:-1, max-image-preview:large", []RobotsDirective{{Source: "header", Bot: "robots", Directives: []string{"max-snippet:-1", "max-image-preview:large"}}}},
+		{
+			"unavailable_after: Sunday, 01-Jan-2027 00:00:00 GMT",
+			[]RobotsDirective{{Source: "header", Bot: "robots", Directives: []string{"unavailable_after: sunday, 01-jan-2027 00:00:00 gmt"}}},
+		},
+		{
+			"googlebot: unavailable_after: Sunday, 01-Jan-2027 00:00:00 GMT, noindex, bingbot: nofollow",
+			[]RobotsDirective{
+				{Source: "header", Bot: "googlebot", Directives: []string{"unavailable_after: sunday, 01-jan-2027 00:00:00 gmt", "noindex"}},
+				{Source: "header", Bot: "bingbot", Directives: []string{"nofollow"}},
+			},
+		},
+		{"googlebot:", nil},
+	}
+
+	for _, tt := range tests {
+		if got := parseXRobotsTag(tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseXRobotsTag(%q) = %+v, очікувалось %+v", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestSplitDirectives(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []string
+	}{
+		{"NOINDEX, Follow", []string{"noindex", "follow"}},
+		{" , ", nil},
+		{"index, unavailable_after: 25 Jun 2026 15:00:00 PST", []string{"index", "unavailable_after: 25 jun 2026 15:00:00 pst"}},
+		{"unavailable_after: Sunday, 01-Jan-2027 00:00:00 GMT, noindex", []string{"unavailable_after: sunday, 01-jan-2027 00:00:00 gmt", "noindex"}},
+	}
+	for _, tt := range tests {
+		if got := splitDirectives(tt.content); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("splitDirectives(%q) = %q, очікувалось %q", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestCheckIndexability(t *testing.T) {
+	tests := []struct {
+		name      string
+		status    int
+		header    http.Header
+		head      string
+		canonical string
+		blocked   bool
+		redirects []string
+		want      []string // Коди причин
+	}{
+		{"індексується", http.StatusOK, nil, `<meta name="robots" content="index, follow">`, "", false, nil, nil},
+		{"noindex у meta", http.StatusOK, nil, `<meta name="robots" content="noindex">`, "", false, nil, []string{ReasonNoindex}},
+		{"none для бота з INDEXING_BOTS", http.StatusOK, nil, `<meta name="googlebot" content="none">`, "", false, nil, []string{ReasonNoindex}},
+		{"noindex для іншого бота ігнорується", http.StatusOK, nil, `<meta name="otherbot" content="noindex">`, "", false, nil, nil},
+		{"noindex у X-Robots-Tag", http.StatusOK, http.Header{"X-Robots-Tag": {"googlebot: noindex"}}, "", "", false, nil, []string{ReasonNoindex}},
+		{
+			"unavailable_after з датою не є noindex", http.StatusOK,
+			http.Header{"X-Robots-Tag": {"unavailable_after: Sunday, 01-Jan-2027 00:00:00 GMT"}}, "", "", false, nil, nil,
+		},
+		{"канонічна інша сторінка", http.StatusOK, nil, "", "https://example.com/other/", false, nil, []string{ReasonCanonicalized}},
+		{"канонічна сама сторінка", http.StatusOK, nil, "", "https://example.com/page/", false, nil, nil},
+		{
+			"кілька причин", http.StatusNotFound, nil, "", "", true, []string{"https://example.com/new/"},
+			[]string{ReasonStatus, ReasonRobotsTxt, ReasonRedirect},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig(t, map[string]string{"INDEXING_BOTS": "googlebot"})
+			header := tt.header
+			if header == nil {
+				header = http.Header{}
+			}
+			page := &Page{
+				Entry:    parser.URL{Loc: "https://example.com/page/"},
+				FinalURL: "https://example.com/page/",
+				Response: &fetcher.Response{URL: "https://example.com/page/", StatusCode: tt.status, Header: header},
+				Document: htmldoc.Parse([]byte("<html><head>" + tt.head + "</head><body></body></html>")),
+			}
+			result := &PageResult{StatusCode: tt.status, CanonicalURL: tt.canonical, IsBlockedByRobotsTxt: tt.blocked, Redirects: tt.redirects}
+			(&Checker{cfg: cfg}).checkIndexability(context.Background(), page, result)
+
+			var got []string
+			for _, reason := range result.Indexability.Reasons {
+				got = append(got, reason.Code)
+			}
+			if !reflect.DeepEqual(got, tt.want) || result.Indexability.Indexable != (len(tt.want) == 0) {
+				t.Errorf("indexability = %+v, очікувались причини %v", result.Indexability, tt.want)
+			}
+		})
+	}
+}
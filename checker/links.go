@@ -0,0 +1,180 @@
+package checker
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"sitemap-checker/htmldoc"
+)
+
+// FindingBrokenLink — сторінка посилається на недоступну внутрішню адресу
+const FindingBrokenLink = "broken_link"
+
+// Link — внутрішнє посилання сторінки
+type Link struct {
+	URL      string // Абсолютний URL без фрагмента
+	Anchor   string // Текст посилання
+	Nofollow bool   // rel="nofollow"
+}
+
+// LinkSource — сторінка, що містить посилання
+type LinkSource struct {
+	Page   string `json:"page"`
+	Anchor string `json:"anchor"`
+}
+
+// BrokenLink — недоступна внутрішня адреса та сторінки, що на неї посилаються
+type BrokenLink struct {
+	URL        string       `json:"url"`
+	StatusCode int          `json:"status_code,omitempty"`
+	Error      string       `json:"error,omitempty"`
+	Sources    []LinkSource `json:"sources"`
+}
+
+// checkLinks збирає внутрішні посилання сторінки та додає її до графа посилань
+func (c *Checker) checkLinks(ctx context.Context, page *Page, result *PageResult) {
+	result.links = c.internalLinks(page.FinalURL, page.Document)
+
+	// Сторінки з sitemap уже завантажені, тому під час обходу повторно не завантажуються
+	target := c.linkTarget(page.Entry.Loc)
+	target.once.Do(func() {
+		target.statusCode = page.Response.StatusCode
+		target.redirects = c.restoreURLs(page.Response.Redirects)
+		target.indexable = result.Indexability.Indexable
+		target.links = result.links
+		target.crawled = true
+	})
+
+	c.linkMutex.Lock()
+	target.inSitemap = true
+	c.linkMutex.Unlock()
+}
+
+// internalLinks повертає посилання <a href> сторінки, що входять в область обходу;
+// посилання розв'язуються відносно оригінальної адреси сторінки pageURL, тож
+// правила переписування до них застосовуються лише під час завантаження
+func (c *Checker) internalLinks(pageURL string, doc *htmldoc.Document) []Link {
+	base := documentBase(pageURL, doc)
+	if base == nil {
+		return nil
+	}
+
+	var links []Link
+	for _, el := range doc.Find("a") {
+		href, ok := el.LookupAttr("href")
+		href = strings.TrimSpace(href)
+		if !ok || href == "" || strings.HasPrefix(href, "#") {
+			continue
+		}
+
+		resolved := resolveURL(base, href)
+		if resolved == "" || !c.inScope(pageURL, resolved) {
+			continue
+		}
+
+		links = append(links, Link{URL: resolved, Anchor: anchorText(doc, el), Nofollow: el.HasToken("rel", "nofollow")})
+	}
+	return links
+}
+
+// blockElements — блокові елементи, на яких обривається текст незакритого посилання
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "dd": true,
+	"div": true, "dl": true, "dt": true, "fieldset": true, "figure": true, "footer": true,
+	"form": true, "h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"header": true, "hr": true, "li": true, "main": true, "nav": true, "ol": true,
+	"p": true, "pre": true, "section": true, "table": true, "td": true, "th": true,
+	"tr": true, "ul": true,
+}
+
+// anchorText повертає текст посилання або, якщо його немає, aria-label, title чи alt зображення
+func anchorText(doc *htmldoc.Document, el *htmldoc.Element) string {
+	extent := linkExtent(doc, el)
+	if text := doc.InnerText(extent); text != "" {
+		return text
+	}
+	for _, key := range []string{"aria-label", "title"} {
+		if v := strings.TrimSpace(el.Attr(key)); v != "" {
+			return v
+		}
+	}
+	for _, img := range doc.Find("img") {
+		if img.Start >= extent.Start && img.End <= extent.End {
+			return strings.TrimSpace(img.Attr("alt"))
+		}
+	}
+	return ""
+}
+
+// linkExtent повертає межі посилання для тексту: незакрите <a> тягнеться до
+// кінця батьківського елемента, тому його текст обривається на першому блоковому елементі
+func linkExtent(doc *htmldoc.Document, el *htmldoc.Element) *htmldoc.Element {
+	if el.Closed {
+		return el
+	}
+	for _, next := range doc.Elements {
+		if next.Start > el.Start && next.Start < el.End && blockElements[next.Tag] {
+			extent := *el
+			extent.End = next.Start
+			return &extent
+		}
+	}
+	return el
+}
+
+// reportBrokenLinks перелічує недоступні внутрішні адреси разом зі сторінками-джерелами
+func (c *Checker) reportBrokenLinks(report *Report) {
+	report.BrokenLinks = make([]BrokenLink, 0)
+
+	pageIndex := make(map[string]int, len(report.Pages))
+	for i, page := range report.Pages {
+		pageIndex[page.URL] = i
+	}
+
+	broken := make(map[string]*BrokenLink)
+	var order []string
+	for _, source := range c.crawledTargets() {
+		reported := make(map[string]bool)
+		for _, link := range source.links {
+			key := link.URL
+			c.linkMutex.Lock()
+			target, checked := c.linkTargets[key]
+			c.linkMutex.Unlock()
+			if !checked || !target.broken() {
+				continue
+			}
+
+			entry, exists := broken[key]
+			if !exists {
+				entry = &BrokenLink{URL: link.URL, StatusCode: target.statusCode}
+				if target.err != nil {
+					entry.Error = target.err.Error()
+				}
+				broken[key] = entry
+				order = append(order, key)
+			}
+			entry.Sources = append(entry.Sources, LinkSource{Page: source.url, Anchor: link.Anchor})
+
+			// Знахідки додаються лише до сторінок з sitemap
+			i, inReport := pageIndex[source.url]
+			if !inReport || reported[key] {
+				continue
+			}
+			reported[key] = true
+			page := &report.Pages[i]
+			if target.err != nil {
+				page.addFinding(FindingBrokenLink, SeverityError, link.URL, "посилання %q веде на недоступну адресу: %v", link.Anchor, target.err)
+			} else {
+				page.addFinding(FindingBrokenLink, SeverityError, link.URL, "посилання %q веде на сторінку зі статусом %d", link.Anchor, target.statusCode)
+			}
+		}
+	}
+
+	sort.Strings(order)
+	for _, key := range order {
+		entry := broken[key]
+		sort.SliceStable(entry.Sources, func(i, j int) bool { return entry.Sources[i].Page < entry.Sources[j].Page })
+		report.BrokenLinks = append(report.BrokenLinks, *entry)
+	}
+}
@@ -0,0 +1,37 @@
+package checker
+
+import (
+	"testing"
+
+	"sitemap-checker/htmldoc"
+)
+
+func TestAnchorText(t *testing.T) {
+	tests := []struct {
+		name string
+		html string
+		want []string
+	}{
+		{"закрите посилання", `<p><a href="/a">Розділ A</a> далі</p>`, []string{"Розділ A"}},
+		{"блок усередині посилання", `<a href="/a"><div>Картка</div></a><p>Текст</p>`, []string{"Картка"}},
+		{"незакрите перед наступним посиланням", `<body><a href="/a">Перше <a href="/b">Друге</a></body>`, []string{"Перше", "Друге"}},
+		{"незакрите перед блоком", `<body><a href="/a">Розділ<div>Увесь інший вміст сторінки</div></body>`, []string{"Розділ"}},
+		{"незакрите до кінця документа", `<a href="/a">Розділ<p>Решта документа`, []string{"Розділ"}},
+		{"alt зображення за межами незакритого посилання", `<a href="/a"><p><img alt="Банер">`, []string{""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc := htmldoc.Parse([]byte(tt.html))
+			links := doc.Find("a")
+			if len(links) != len(tt.want) {
+				t.Fatalf("знайдено %d посилань, очікувалось %d", len(links), len(tt.want))
+			}
+			for i, el := range links {
+				if got := anchorText(doc, el); got != tt.want[i] {
+					t.Errorf("посилання %d: текст %q, очікувалось %q", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
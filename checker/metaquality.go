@@ -0,0 +1,226 @@
+package checker
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Типи знахідок щодо заголовка та мета-опису
+const (
+	FindingTitleMissing         = "title_missing"
+	FindingTitleEmpty           = "title_empty"
+	FindingTitleTooShort        = "title_too_short"
+	FindingTitleTooLong         = "title_too_long"
+	FindingTitleDuplicate       = "title_duplicate"
+	FindingDescriptionMissing   = "description_missing"
+	FindingDescriptionEmpty     = "description_empty"
+	FindingDescriptionTooShort  = "description_too_short"
+	FindingDescriptionTooLong   = "description_too_long"
+	FindingDescriptionDuplicate = "description_duplicate"
+)
+
+// MetaIssue — проблема із заголовком або мета-описом сторінки
+type MetaIssue struct {
+	URL     string  `json:"url"`
+	Type    string  `json:"type"`
+	Value   string  `json:"value,omitempty"`
+	Length  int     `json:"length"`
+	Pixels  float64 `json:"pixels"`
+	Message string  `json:"message"`
+}
+
+// DuplicateGroup — сторінки зі спільним значенням
+type DuplicateGroup struct {
+	Value string   `json:"value"`
+	URLs  []string `json:"urls"`
+}
+
+// MetaFieldReport — підсумок для одного поля (title або description)
+type MetaFieldReport struct {
+	Issues     []MetaIssue      `json:"issues"`
+	Duplicates []DuplicateGroup `json:"duplicates"`
+}
+
+// MetaQualityReport — якість заголовків та мета-описів по всьому sitemap
+type MetaQualityReport struct {
+	Titles       MetaFieldReport `json:"titles"`
+	Descriptions MetaFieldReport `json:"descriptions"`
+}
+
+// metaField описує поле, що перевіряється, та його ліміти
+type metaField struct {
+	Key       string // Ключ у PageResult.MetaTags
+	Name      string // Назва для повідомлень
+	MinLength int
+	MaxLength int
+	MaxPixels float64
+	FontSize  float64 // Розмір шрифту у видачі, px
+
+	Missing, Empty, TooShort, TooLong, Duplicate string // Типи знахідок
+}
+
+// reportMetaQuality перевіряє заголовки та мета-описи після завершення обходу
+func (c *Checker) reportMetaQuality(report *Report) {
+	titles := metaField{
+		Key:       "title",
+		Name:      "заголовок",
+		MinLength: c.cfg.TitleMinLength,
+		MaxLength: c.cfg.TitleMaxLength,
+		MaxPixels: c.cfg.TitleMaxPixels,
+		FontSize:  titleFontSize,
+		Missing:   FindingTitleMissing,
+		Empty:     FindingTitleEmpty,
+		TooShort:  FindingTitleTooShort,
+		TooLong:   FindingTitleTooLong,
+		Duplicate: FindingTitleDuplicate,
+	}
+	descriptions := metaField{
+		Key:       "description",
+		Name:      "мета-опис",
+		MinLength: c.cfg.DescriptionMinLength,
+		MaxLength: c.cfg.DescriptionMaxLength,
+		MaxPixels: c.cfg.DescriptionMaxPixels,
+		FontSize:  descriptionFontSize,
+		Missing:   FindingDescriptionMissing,
+		Empty:     FindingDescriptionEmpty,
+		TooShort:  FindingDescriptionTooShort,
+		TooLong:   FindingDescriptionTooLong,
+		Duplicate: FindingDescriptionDuplicate,
+	}
+
+	report.MetaQuality = &MetaQualityReport{
+		Titles:       analyzeMetaField(report.Pages, titles),
+		Descriptions: analyzeMetaField(report.Pages, descriptions),
+	}
+}
+
+// analyzeMetaField перевіряє поле на всіх сторінках, додаючи знахідки до них
+func analyzeMetaField(pages []PageResult, field metaField) MetaFieldReport {
+	fieldReport := MetaFieldReport{Issues: make([]MetaIssue, 0), Duplicates: make([]DuplicateGroup, 0)}
+	groups := make(map[string]*DuplicateGroup)
+	var order []string
+
+	for i := range pages {
+		page := &pages[i]
+		// Сторінки з помилками, редіректами та документи, що не є HTML, не мають заголовка для оцінки
+		if page.StatusCode < 200 || page.StatusCode > 299 || len(page.Redirects) > 0 || !isHTML(page.ContentType) {
+			continue
+		}
+
+		addIssue := func(issueType, severity, value, format string, args ...interface{}) {
+			page.addFinding(issueType, severity, "", format, args...)
+			fieldReport.Issues = append(fieldReport.Issues, MetaIssue{
+				URL:     page.URL,
+				Type:    issueType,
+				Value:   value,
+				Length:  len([]rune(value)),
+				Pixels:  pixelWidth(value, field.FontSize),
+				Message: page.Findings[len(page.Findings)-1].Message,
+			})
+		}
+
+		value, ok := page.MetaTags[field.Key]
+		value = collapseSpace(value)
+		length := len([]rune(value))
+		pixels := pixelWidth(value, field.FontSize)
+
+		switch {
+		case !ok:
+			addIssue(field.Missing, SeverityError, "", "%s відсутній", field.Name)
+			continue
+		case value == "":
+			addIssue(field.Empty, SeverityError, "", "%s порожній", field.Name)
+			continue
+		case field.MinLength > 0 && length < field.MinLength:
+			addIssue(field.TooShort, SeverityWarning, value, "%s закороткий: довжина %d, мінімум %d", field.Name, length, field.MinLength)
+		case field.MaxLength > 0 && length > field.MaxLength:
+			addIssue(field.TooLong, SeverityWarning, value, "%s задовгий: довжина %d, максимум %d", field.Name, length, field.MaxLength)
+		case field.MaxPixels > 0 && pixels > field.MaxPixels:
+			addIssue(field.TooLong, SeverityWarning, value, "%s задовгий: ~%.0f px, максимум %.0f", field.Name, pixels, field.MaxPixels)
+		}
+
+		// Дублікати шукаємо лише серед сторінок, що індексуються
+		if !page.Indexability.Indexable {
+			continue
+		}
+		key := strings.ToLower(value)
+		group, exists := groups[key]
+		if !exists {
+			group = &DuplicateGroup{Value: value}
+			groups[key] = group
+			order = append(order, key)
+		}
+		group.URLs = append(group.URLs, page.URL)
+	}
+
+	// Позначаємо сторінки зі спільним значенням
+	urlIndex := make(map[string]int, len(pages))
+	for i := range pages {
+		urlIndex[pages[i].URL] = i
+	}
+	for _, key := range order {
+		group := groups[key]
+		if len(group.URLs) < 2 {
+			continue
+		}
+		sort.Strings(group.URLs)
+		for _, u := range group.URLs {
+			page := &pages[urlIndex[u]]
+			page.addFinding(field.Duplicate, SeverityWarning, "", "%s збігається з іншими сторінками: %d", field.Name, len(group.URLs)-1)
+		}
+		fieldReport.Duplicates = append(fieldReport.Duplicates, *group)
+	}
+	sort.Slice(fieldReport.Duplicates, func(i, j int) bool {
+		return len(fieldReport.Duplicates[i].URLs) > len(fieldReport.Duplicates[j].URLs)
+	})
+
+	return fieldReport
+}
+
+// collapseSpace замінює послідовності пробільних символів одним пробілом
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// arialWidths — ширина символів ASCII у шрифті Arial в частках em
+var arialWidths = map[rune]float64{
+	' ': 0.278, '!': 0.278, '"': 0.355, '#': 0.556, '$': 0.556, '%': 0.889, '&': 0.667, '\'': 0.191,
+	'(': 0.333, ')': 0.333, '*': 0.389, '+': 0.584, ',': 0.278, '-': 0.333, '.': 0.278, '/': 0.278,
+	':': 0.278, ';': 0.278, '<': 0.584, '=': 0.584, '>': 0.584, '?': 0.556, '@': 1.015, '[': 0.278,
+	'\\': 0.278, ']': 0.278, '^': 0.469, '_': 0.556, '`': 0.333, '{': 0.334, '|': 0.26, '}': 0.334, '~': 0.584,
+	'A': 0.667, 'B': 0.667, 'C': 0.722, 'D': 0.722, 'E': 0.667, 'F': 0.611, 'G': 0.778, 'H': 0.722,
+	'I': 0.278, 'J': 0.5, 'K': 0.667, 'L': 0.556, 'M': 0.833, 'N': 0.722, 'O': 0.778, 'P': 0.667,
+	'Q': 0.778, 'R': 0.722, 'S': 0.667, 'T': 0.611, 'U': 0.722, 'V': 0.667, 'W': 0.944, 'X': 0.667,
+	'Y': 0.667, 'Z': 0.611,
+	'a': 0.556, 'b': 0.556, 'c': 0.5, 'd': 0.556, 'e': 0.556, 'f': 0.278, 'g': 0.556, 'h': 0.556,
+	'i': 0.222, 'j': 0.222, 'k': 0.5, 'l': 0.222, 'm': 0.833, 'n': 0.556, 'o': 0.556, 'p': 0.556,
+	'q': 0.556, 'r': 0.333, 's': 0.5, 't': 0.278, 'u': 0.556, 'v': 0.5, 'w': 0.722, 'x': 0.5,
+	'y': 0.5, 'z': 0.5,
+}
+
+// Розміри шрифтів у видачі пошукової системи, px
+const (
+	titleFontSize       = 20
+	descriptionFontSize = 14
+)
+
+// pixelWidth приблизно оцінює ширину рядка у видачі пошукової системи
+func pixelWidth(s string, fontSize float64) float64 {
+	var em float64
+	for _, r := range s {
+		switch w, ok := arialWidths[r]; {
+		case ok:
+			em += w
+		case r >= '0' && r <= '9':
+			em += 0.556
+		case unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) || unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r):
+			em += 1.0
+		case unicode.IsUpper(r):
+			em += 0.7
+		default:
+			em += 0.556
+		}
+	}
+	return em * fontSize
+}
@@ -0,0 +1,161 @@
+package checker
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+
+	"sitemap-checker/htmldoc"
+)
+
+// Типи знахідок щодо змішаного вмісту
+const (
+	FindingMixedContentActive  = "mixed_content_active"
+	FindingMixedContentPassive = "mixed_content_passive"
+)
+
+// Категорії змішаного вмісту
+const (
+	MixedActive  = "active"  // Блокується браузерами: скрипти, стилі, фрейми, шрифти, форми
+	MixedPassive = "passive" // Завантажується з попередженням: зображення, аудіо, відео
+)
+
+// MixedContent — ресурс HTTPS-сторінки, що завантажується через http://
+type MixedContent struct {
+	URL     string `json:"url"`
+	Element string `json:"element"` // Тег та атрибут, наприклад script[src]
+	Kind    string `json:"kind"`    // active або passive
+}
+
+// MixedContentPage — кількість змішаного вмісту на сторінці
+type MixedContentPage struct {
+	URL     string `json:"url"`
+	Active  int    `json:"active"`
+	Passive int    `json:"passive"`
+}
+
+// MixedContentReport — підсумок змішаного вмісту за всіма сторінками
+type MixedContentReport struct {
+	Active  int                `json:"active"`
+	Passive int                `json:"passive"`
+	Pages   []MixedContentPage `json:"pages"`
+}
+
+// checkMixedContent шукає на HTTPS-сторінці ресурси, що завантажуються через http://;
+// схема береться з оригінальної адреси, бо staging (https://prod=http://staging)
+// може віддавати сторінки через http
+func (c *Checker) checkMixedContent(ctx context.Context, page *Page, result *PageResult) {
+	pageURL, err := url.Parse(page.FinalURL)
+	if err != nil || pageURL.Scheme != "https" {
+		return
+	}
+	base := documentBase(page.FinalURL, page.Document)
+
+	seen := make(map[string]bool)
+	for _, el := range page.Document.Elements {
+		for _, ref := range mixedContentRefs(el) {
+			resolved := resolveURL(base, strings.TrimSpace(ref.url))
+			if !strings.HasPrefix(resolved, "http://") {
+				continue
+			}
+			element := el.Tag + "[" + ref.attr + "]"
+			key := element + " " + resolved
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+
+			result.MixedContent = append(result.MixedContent, MixedContent{URL: resolved, Element: element, Kind: ref.kind})
+			if ref.kind == MixedActive {
+				result.addFinding(FindingMixedContentActive, SeverityError, resolved, "активний змішаний вміст %s", element)
+			} else {
+				result.addFinding(FindingMixedContentPassive, SeverityWarning, resolved, "пасивний змішаний вміст %s", element)
+			}
+		}
+	}
+}
+
+// mixedContentRef — адреса ресурсу в атрибуті елемента
+type mixedContentRef struct {
+	attr string
+	url  string
+	kind string
+}
+
+// mixedContentRefs повертає адреси ресурсів елемента з категорією за правилами
+// браузерів: пасивними вважаються лише зображення (<img src>, favicon), аудіо та
+// відео; srcset та <picture> браузери блокують як активний вміст
+func mixedContentRefs(el *htmldoc.Element) []mixedContentRef {
+	var refs []mixedContentRef
+	attr := func(name, kind string) {
+		if v, ok := el.LookupAttr(name); ok && strings.TrimSpace(v) != "" {
+			refs = append(refs, mixedContentRef{attr: name, url: v, kind: kind})
+		}
+	}
+	srcset := func() {
+		for _, candidate := range srcsetURLs(el.Attr("srcset")) {
+			refs = append(refs, mixedContentRef{attr: "srcset", url: candidate, kind: MixedActive})
+		}
+	}
+
+	switch el.Tag {
+	case "script", "iframe", "frame", "embed":
+		attr("src", MixedActive)
+	case "object":
+		attr("data", MixedActive)
+	case "form":
+		attr("action", MixedActive)
+	case "button", "input":
+		attr("formaction", MixedActive)
+	case "link":
+		switch {
+		case el.HasToken("rel", "icon") || el.HasToken("rel", "apple-touch-icon"):
+			attr("href", MixedPassive)
+		case el.HasToken("rel", "stylesheet") || el.HasToken("rel", "preload") || el.HasToken("rel", "modulepreload") || el.HasToken("rel", "manifest"):
+			attr("href", MixedActive)
+		}
+	case "img":
+		attr("src", MixedPassive)
+		srcset()
+	case "audio", "video":
+		attr("src", MixedPassive)
+		attr("poster", MixedPassive)
+	case "source":
+		if el.Parent != nil && el.Parent.Tag == "picture" {
+			srcset()
+		} else {
+			attr("src", MixedPassive)
+		}
+	case "track":
+		attr("src", MixedActive)
+	}
+	return refs
+}
+
+// reportMixedContent підсумовує змішаний вміст за сторінками
+func (c *Checker) reportMixedContent(report *Report) {
+	report.MixedContent = &MixedContentReport{Pages: make([]MixedContentPage, 0)}
+
+	for _, page := range report.Pages {
+		if len(page.MixedContent) == 0 {
+			continue
+		}
+		stats := MixedContentPage{URL: page.URL}
+		for _, item := range page.MixedContent {
+			if item.Kind == MixedActive {
+				stats.Active++
+			} else {
+				stats.Passive++
+			}
+		}
+		report.MixedContent.Active += stats.Active
+		report.MixedContent.Passive += stats.Passive
+		report.MixedContent.Pages = append(report.MixedContent.Pages, stats)
+	}
+
+	sort.Slice(report.MixedContent.Pages, func(i, j int) bool {
+		a, b := report.MixedContent.Pages[i], report.MixedContent.Pages[j]
+		return a.Active > b.Active || a.Active == b.Active && a.URL < b.URL
+	})
+}
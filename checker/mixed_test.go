@@ -0,0 +1,79 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"sitemap-checker/fetcher"
+	"sitemap-checker/htmldoc"
+	"sitemap-checker/parser"
+)
+
+func TestCheckMixedContent(t *testing.T) {
+	tests := []struct {
+		name     string
+		pageURL  string // Оригінальна адреса сторінки
+		fetchURL string // Адреса, за якою сторінку завантажено
+		body     string
+		want     []MixedContent
+	}{
+		{
+			"активний і пасивний вміст",
+			"https://example.com/", "https://example.com/",
+			`<script src="http://cdn.example.com/app.js"></script><img src="http://cdn.example.com/a.png"><img src="https://cdn.example.com/b.png">`,
+			[]MixedContent{
+				{URL: "http://cdn.example.com/app.js", Element: "script[src]", Kind: MixedActive},
+				{URL: "http://cdn.example.com/a.png", Element: "img[src]", Kind: MixedPassive},
+			},
+		},
+		{
+			"srcset блокується як активний",
+			"https://example.com/", "https://example.com/",
+			`<img src="/a.png" srcset="http://cdn.example.com/a-2x.png 2x">`,
+			[]MixedContent{{URL: "http://cdn.example.com/a-2x.png", Element: "img[srcset]", Kind: MixedActive}},
+		},
+		{
+			"форма та favicon",
+			"https://example.com/", "https://example.com/",
+			`<link rel="icon" href="http://example.com/favicon.ico"><form action="http://example.com/login"></form>`,
+			[]MixedContent{
+				{URL: "http://example.com/favicon.ico", Element: "link[href]", Kind: MixedPassive},
+				{URL: "http://example.com/login", Element: "form[action]", Kind: MixedActive},
+			},
+		},
+		{
+			"повтори не дублюються",
+			"https://example.com/", "https://example.com/",
+			`<img src="http://cdn.example.com/a.png"><img src="http://cdn.example.com/a.png">`,
+			[]MixedContent{{URL: "http://cdn.example.com/a.png", Element: "img[src]", Kind: MixedPassive}},
+		},
+		{"HTTP-сторінка", "http://example.com/", "http://example.com/", `<script src="http://cdn.example.com/app.js"></script>`, nil},
+		{
+			"staging через http",
+			"https://example.com/", "http://staging.local/",
+			`<script src="http://cdn.example.com/app.js"></script><img src="/a.png">`,
+			[]MixedContent{{URL: "http://cdn.example.com/app.js", Element: "script[src]", Kind: MixedActive}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := &Page{
+				Entry:    parser.URL{Loc: tt.pageURL},
+				FinalURL: tt.pageURL,
+				Response: &fetcher.Response{URL: tt.fetchURL, StatusCode: http.StatusOK},
+				Document: htmldoc.Parse([]byte("<html><body>" + tt.body + "</body></html>")),
+			}
+			result := &PageResult{}
+			(&Checker{}).checkMixedContent(context.Background(), page, result)
+			if !reflect.DeepEqual(result.MixedContent, tt.want) {
+				t.Errorf("mixed_content = %+v, очікувалось %+v", result.MixedContent, tt.want)
+			}
+			if len(result.Findings) != len(tt.want) {
+				t.Errorf("знахідок %d, очікувалось %d", len(result.Findings), len(tt.want))
+			}
+		})
+	}
+}
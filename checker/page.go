@@ -0,0 +1,123 @@
+package checker
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"sitemap-checker/fetcher"
+	"sitemap-checker/htmldoc"
+	"sitemap-checker/parser"
+)
+
+// Page — завантажена сторінка, спільна для всіх перевірок
+type Page struct {
+	Entry    parser.URL        // Запис із sitemap
+	URL      string            // URL після переписування, за яким завантажено сторінку
+	FinalURL string            // Оригінальна адреса сторінки після редіректів: база для посилань документа
+	Response *fetcher.Response // Відповідь сервера
+	Document *htmldoc.Document // Розібраний HTML, один на всі перевірки
+}
+
+// pageCheck — перевірка сторінки, що доповнює її результат
+type pageCheck func(c *Checker, ctx context.Context, page *Page, result *PageResult)
+
+// pageChecks — перевірки, які виконуються для кожної завантаженої HTML-сторінки
+var pageChecks = []pageCheck{
+	(*Checker).checkContentType,
+	(*Checker).checkHead,
+	(*Checker).checkContent,
+	(*Checker).checkCanonical,
+	(*Checker).checkHreflang,
+	(*Checker).checkSocial,
+	(*Checker).checkStructuredData,
+	(*Checker).checkResources,
+	(*Checker).checkMixedContent,
+	(*Checker).checkSoft404, // Після checkContent: використовує відбиток SimHash
+	(*Checker).checkSecurityHeaders,
+	(*Checker).checkCaching,
+	(*Checker).checkIndexability,
+	(*Checker).checkLinks, // Після checkIndexability: граф посилань використовує вердикт
+}
+
+// documentChecks — перевірки документів, що не є HTML (PDF, зображення тощо):
+// враховуються лише заголовки відповіді (Link, кешування, X-Robots-Tag) та її розмір
+var documentChecks = []pageCheck{
+	(*Checker).checkContentType,
+	(*Checker).checkCanonical,
+	(*Checker).checkCaching,
+	(*Checker).checkIndexability,
+	(*Checker).checkLinks,
+}
+
+// checkPage завантажує сторінку з файлу sitemap і виконує всі перевірки
+func (c *Checker) checkPage(ctx context.Context, entry parser.URL, sitemap string) (*PageResult, error) {
+	// Переписуємо URL згідно з правилами (наприклад, prod → staging)
+	fetchURL := c.cfg.Rewrites.Apply(entry.Loc)
+
+	// Перевірка robots.txt
+	robotsResult := c.CheckRobotsTxt(ctx, fetchURL)
+
+	// Завантажуємо сторінку з вимірюванням часу
+	resp, err := c.fetcher.Fetch(ctx, &fetcher.Request{URL: fetchURL})
+	if err != nil {
+		return nil, err
+	}
+
+	// Перевірка часу завантаження
+	CheckPageLoadTime(entry.Loc, resp.LoadTime, 2*time.Second) // Поріг: 2 секунди
+
+	// Документи, що не є HTML, не розбираються: перевірки отримують порожній документ
+	html := isHTML(resp.Header.Get("Content-Type"))
+	doc := &htmldoc.Document{}
+	if html {
+		doc = htmldoc.Parse(resp.Body)
+	}
+
+	page := &Page{
+		Entry:    entry,
+		URL:      fetchURL,
+		FinalURL: c.responseURL(entry.Loc, resp),
+		Response: resp,
+		Document: doc,
+	}
+
+	// Збір даних про сторінку
+	result := &PageResult{
+		URL:                  entry.Loc,
+		RewrittenURL:         fetchURL,
+		Sitemap:              sitemap,
+		StatusCode:           resp.StatusCode,
+		Redirects:            resp.Redirects,
+		MetaTags:             make(map[string]string),
+		LoadTime:             resp.LoadTime.String(),
+		IsBlockedByRobotsTxt: !robotsResult.Allowed,
+		RobotsRule:           robotsResult.Rule,
+		Findings:             make([]Finding, 0),
+	}
+
+	checks := documentChecks
+	if html {
+		result.ContentHash = ContentHash(page.Document, c.cfg.ContentNormalization) // Дублі шукаються після перевірки всіх сторінок
+		checks = pageChecks
+	} else if len(resp.Body) > 0 {
+		result.ContentHash = BodyHash(resp.Body) // Однакові PDF та інші файли теж є дублями
+	}
+	for _, check := range checks {
+		check(c, ctx, page, result)
+	}
+
+	return result, nil
+}
+
+// checkHead витягує мета-теги з <head>
+func (c *Checker) checkHead(ctx context.Context, page *Page, result *PageResult) {
+	doc := page.Document
+
+	if doc.HasTitle {
+		result.MetaTags["title"] = doc.Title
+	}
+	if descriptions := doc.Meta("description"); len(descriptions) > 0 {
+		result.MetaTags["description"] = strings.TrimSpace(descriptions[0])
+	}
+}
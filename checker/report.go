@@ -0,0 +1,59 @@
+package checker
+
+// Report — підсумковий звіт перевірки
+type Report struct {
+	Pages          []PageResult           `json:"pages"`
+	Robots         []RobotsReport         `json:"robots"`
+	NonIndexable   []NonIndexablePage     `json:"non_indexable"`
+	MetaQuality    *MetaQualityReport     `json:"meta_quality"`
+	Duplicates     []DuplicateGroup       `json:"duplicates"`
+	NearDuplicates []NearDuplicateCluster `json:"near_duplicates"`
+	BrokenLinks    []BrokenLink           `json:"broken_links"`
+	BrokenAssets   []BrokenAsset          `json:"broken_assets"`
+	MixedContent   *MixedContentReport    `json:"mixed_content"`
+	Soft404        *Soft404Report         `json:"soft_404"`
+	Security       []SecurityHostSummary  `json:"security"`
+	Caching        []CacheHostSummary     `json:"caching"`
+	Coverage       *Coverage              `json:"coverage"`
+	ClickDepth     *ClickDepthReport      `json:"click_depth"`
+}
+
+// reportStage — етап пост-обробки, що виконується після перевірки всіх сторінок
+// і може доповнювати як звіт, так і результати окремих сторінок
+type reportStage func(c *Checker, report *Report)
+
+// reportStages — етапи пост-обробки в порядку виконання
+var reportStages = []reportStage{
+	(*Checker).reportNonIndexable,
+	(*Checker).reportHreflang,
+	(*Checker).reportMetaQuality,
+	(*Checker).reportDuplicates,
+	(*Checker).reportNearDuplicates,
+	(*Checker).reportBrokenLinks,
+	(*Checker).reportBrokenAssets,
+	(*Checker).reportMixedContent,
+	(*Checker).reportSoft404,
+	(*Checker).reportSecurity,
+	(*Checker).reportCaching,
+	(*Checker).reportCoverage,
+	(*Checker).reportClickDepth,
+	(*Checker).reportPageRank,
+}
+
+// Report формує звіт за результатами перевірки
+func (c *Checker) Report() *Report {
+	pages := c.Results()
+	if pages == nil {
+		pages = make([]PageResult, 0)
+	}
+
+	report := &Report{
+		Pages:  pages,
+		Robots: c.robotsReports(),
+	}
+	for _, stage := range reportStages {
+		stage(c, report)
+	}
+
+	return report
+}
@@ -0,0 +1,255 @@
+package checker
+
+import (
+	"context"
+	"mime"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"sitemap-checker/fetcher"
+	"sitemap-checker/htmldoc"
+	"sitemap-checker/logger"
+)
+
+// Типи знахідок щодо ресурсів сторінки
+const (
+	FindingAssetBroken     = "asset_broken"
+	FindingImageMissingAlt = "image_missing_alt"
+)
+
+// Типи ресурсів
+const (
+	AssetImage      = "image"
+	AssetStylesheet = "stylesheet"
+	AssetScript     = "script"
+	AssetFont       = "font"
+	AssetFavicon    = "favicon"
+)
+
+// Asset — ресурс, на який посилається сторінка
+type Asset struct {
+	URL         string `json:"url"`
+	Type        string `json:"type"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Size        int    `json:"size"`
+	Error       string `json:"error,omitempty"`
+}
+
+// PageResources — підсумок ресурсів сторінки
+type PageResources struct {
+	Requests int     `json:"requests"` // Кількість запитів: документ та унікальні ресурси
+	Weight   int     `json:"weight"`   // Загальний розмір документа та ресурсів, байт
+	Broken   []Asset `json:"broken,omitempty"`
+}
+
+// BrokenAsset — недоступний ресурс і сторінки, що його використовують
+type BrokenAsset struct {
+	Asset
+	Pages []string `json:"pages"`
+}
+
+// assetTarget — ресурс, завантажений один раз за запуск
+type assetTarget struct {
+	once  sync.Once
+	asset Asset
+	err   error
+}
+
+// broken повідомляє, чи ресурс недоступний
+func (t *assetTarget) broken() bool {
+	return t.err != nil || t.asset.StatusCode >= 400
+}
+
+// checkResources перевіряє зображення, стилі, скрипти, шрифти та favicon сторінки
+func (c *Checker) checkResources(ctx context.Context, page *Page, result *PageResult) {
+	// Ресурси сторінок помилок і редіректів не перевіряються
+	if page.Response.StatusCode != http.StatusOK {
+		return
+	}
+	doc := page.Document
+
+	// Атрибути alt перевіряються завжди: для цього не потрібні запити
+	for _, img := range doc.Find("img") {
+		if _, ok := img.LookupAttr("alt"); !ok {
+			src := strings.TrimSpace(img.Attr("src"))
+			result.addFinding(FindingImageMissingAlt, SeverityWarning, "", "зображення без атрибута alt: %q", src)
+		}
+	}
+
+	// Завантаження ресурсів — окремі запити в межах TIMEOUT, тому вмикається явно
+	if !c.cfg.CheckResources {
+		return
+	}
+
+	resources := &PageResources{Requests: 1, Weight: len(page.Response.Body)}
+	for _, asset := range pageAssets(page.FinalURL, doc) {
+		target := c.assetTarget(ctx, asset)
+		resources.Requests++
+		if target.broken() {
+			broken := target.asset
+			broken.Type = asset.Type
+			resources.Broken = append(resources.Broken, broken)
+			if target.err != nil {
+				result.addFinding(FindingAssetBroken, SeverityError, asset.URL, "ресурс (%s) недоступний: %v", asset.Type, target.err)
+			} else {
+				result.addFinding(FindingAssetBroken, SeverityError, asset.URL, "ресурс (%s) повертає статус %d", asset.Type, target.asset.StatusCode)
+			}
+			continue
+		}
+		resources.Weight += target.asset.Size
+	}
+	result.Resources = resources
+}
+
+// pageAssets збирає унікальні ресурси сторінки з абсолютними адресами
+func pageAssets(pageURL string, doc *htmldoc.Document) []Asset {
+	base := documentBase(pageURL, doc)
+
+	var assets []Asset
+	seen := make(map[string]bool)
+	add := func(ref, assetType string) {
+		ref = strings.TrimSpace(ref)
+		if ref == "" || strings.HasPrefix(strings.ToLower(ref), "data:") {
+			return
+		}
+		resolved := resolveURL(base, ref)
+		if resolved == "" || !strings.HasPrefix(resolved, "http") || seen[resolved] {
+			return
+		}
+		seen[resolved] = true
+		assets = append(assets, Asset{URL: resolved, Type: assetType})
+	}
+
+	hasFavicon := false
+	for _, el := range doc.Elements {
+		switch el.Tag {
+		case "img":
+			add(el.Attr("src"), AssetImage)
+			for _, candidate := range srcsetURLs(el.Attr("srcset")) {
+				add(candidate, AssetImage)
+			}
+		case "source":
+			if el.Parent != nil && el.Parent.Tag == "picture" {
+				for _, candidate := range srcsetURLs(el.Attr("srcset")) {
+					add(candidate, AssetImage)
+				}
+			}
+		case "script":
+			add(el.Attr("src"), AssetScript)
+		case "link":
+			href := el.Attr("href")
+			switch {
+			case el.HasToken("rel", "stylesheet"):
+				add(href, AssetStylesheet)
+			case el.HasToken("rel", "preload") && strings.EqualFold(el.Attr("as"), "font"):
+				add(href, AssetFont)
+			case el.HasToken("rel", "icon") || el.HasToken("rel", "apple-touch-icon"):
+				hasFavicon = true
+				add(href, AssetFavicon)
+			}
+		}
+	}
+
+	// Без явного <link rel="icon"> браузери запитують /favicon.ico
+	if !hasFavicon && base != nil {
+		add("/favicon.ico", AssetFavicon)
+	}
+
+	return assets
+}
+
+// srcsetURLs повертає адреси кандидатів з атрибута srcset; кома всередині
+// адреси (наприклад, параметри CDN) не розділяє кандидатів
+func srcsetURLs(srcset string) []string {
+	var result []string
+	rest := srcset
+	for {
+		rest = strings.TrimLeft(rest, " \t\n\r\f,")
+		if rest == "" {
+			return result
+		}
+
+		end := strings.IndexAny(rest, " \t\n\r\f")
+		if end < 0 {
+			end = len(rest)
+		}
+		candidate := rest[:end]
+		rest = rest[end:]
+
+		// Кома в кінці адреси завершує кандидата без дескриптора
+		if trimmed := strings.TrimRight(candidate, ","); trimmed != candidate {
+			result = append(result, trimmed)
+			continue
+		}
+		result = append(result, candidate)
+
+		// Пропускаємо дескриптори (1x, 480w) до наступної коми
+		if i := strings.IndexByte(rest, ','); i >= 0 {
+			rest = rest[i+1:]
+		} else {
+			return result
+		}
+	}
+}
+
+// assetTarget завантажує ресурс один раз за запуск
+func (c *Checker) assetTarget(ctx context.Context, asset Asset) *assetTarget {
+	key := c.cfg.Rewrites.Apply(asset.URL)
+
+	c.assetMutex.Lock()
+	target, ok := c.assetTargets[key]
+	if !ok {
+		target = &assetTarget{asset: Asset{URL: asset.URL, Type: asset.Type}}
+		c.assetTargets[key] = target
+	}
+	c.assetMutex.Unlock()
+
+	target.once.Do(func() {
+		resp, err := c.fetcher.Fetch(ctx, &fetcher.Request{URL: key})
+		if err != nil {
+			logger.Error("помилка при завантаженні ресурсу %s: %v", asset.URL, err)
+			target.err = err
+			target.asset.Error = err.Error()
+			return
+		}
+
+		target.asset.StatusCode = resp.StatusCode
+		target.asset.Size = len(resp.Body)
+		if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+			target.asset.ContentType = mediaType
+		}
+	})
+	return target
+}
+
+// reportBrokenAssets перелічує недоступні ресурси разом зі сторінками, що їх використовують
+func (c *Checker) reportBrokenAssets(report *Report) {
+	report.BrokenAssets = make([]BrokenAsset, 0)
+
+	byURL := make(map[string]*BrokenAsset)
+	var order []string
+	for _, page := range report.Pages {
+		if page.Resources == nil {
+			continue
+		}
+		for _, asset := range page.Resources.Broken {
+			entry, ok := byURL[asset.URL]
+			if !ok {
+				entry = &BrokenAsset{Asset: asset}
+				byURL[asset.URL] = entry
+				order = append(order, asset.URL)
+			}
+			entry.Pages = append(entry.Pages, page.URL)
+		}
+	}
+
+	sort.Strings(order)
+	for _, u := range order {
+		entry := byURL[u]
+		sort.Strings(entry.Pages)
+		report.BrokenAssets = append(report.BrokenAssets, *entry)
+	}
+}
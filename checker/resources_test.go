@@ -0,0 +1,77 @@
+package checker
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+// TestCheckResourcesAlt перевіряє, що знахідки image_missing_alt з'являються без
+// CHECK_RESOURCES і без жодного запиту до ресурсів
+func TestCheckResourcesAlt(t *testing.T) {
+	f := &fakeFetcher{responses: map[string]fakeResponse{
+		"https://example.com/sitemap.xml": {
+			header: http.Header{"Content-Type": {"application/xml"}},
+			body: `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>https://example.com/</loc></url></urlset>`,
+		},
+		"https://example.com/": {body: `<html><head><title>Головна сторінка сайту</title></head><body>
+<img src="/logo.png"><img src="/spacer.gif" alt=""></body></html>`},
+	}}
+	report := runChecker(t, testConfig(t, nil), f)
+
+	page := findPage(t, report, "https://example.com/")
+	missing := 0
+	for _, finding := range page.Findings {
+		if finding.Type == FindingImageMissingAlt {
+			missing++
+		}
+	}
+	if missing != 1 {
+		t.Errorf("image_missing_alt = %d, очікувалось 1", missing)
+	}
+	if page.Resources != nil {
+		t.Errorf("resources = %+v без CHECK_RESOURCES", page.Resources)
+	}
+	if f.requested("https://example.com/logo.png") {
+		t.Errorf("ресурс завантажено без CHECK_RESOURCES")
+	}
+}
+
+// TestCheckResourcesRewrite перевіряє, що ресурси завантажуються зі staging один
+// раз, а у звіті мають оригінальні адреси; сторонні ресурси не переписуються
+func TestCheckResourcesRewrite(t *testing.T) {
+	f := &fakeFetcher{responses: map[string]fakeResponse{
+		"https://example.com/preview/sitemap.xml": {
+			header: http.Header{"Content-Type": {"application/xml"}},
+			body: `<?xml version="1.0" encoding="UTF-8"?>
+<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9"><url><loc>https://example.com/</loc></url></urlset>`,
+		},
+		"https://example.com/preview/": {body: `<html><head><title>Головна сторінка сайту</title>
+<link rel="icon" href="/favicon.png"><script src="https://cdn.other.com/app.js"></script></head><body>
+<img src="logo.png" alt="Лого"><img src="/missing.png" alt="Немає"></body></html>`},
+		"https://example.com/preview/favicon.png": {header: http.Header{"Content-Type": {"image/png"}}, body: "png"},
+		"https://example.com/preview/logo.png":    {header: http.Header{"Content-Type": {"image/png"}}, body: "png"},
+		"https://cdn.other.com/app.js":            {header: http.Header{"Content-Type": {"text/javascript"}}, body: "js"},
+	}}
+	cfg := testConfig(t, map[string]string{"CHECK_RESOURCES": "true", "REWRITE_PATHS": "/=/preview/"})
+	report := runChecker(t, cfg, f)
+
+	page := findPage(t, report, "https://example.com/")
+	if page.Resources == nil {
+		t.Fatalf("ресурси не перевірено")
+	}
+	var broken []string
+	for _, asset := range page.Resources.Broken {
+		broken = append(broken, asset.URL)
+	}
+	if want := []string{"https://example.com/missing.png"}; !reflect.DeepEqual(broken, want) {
+		t.Errorf("broken = %v, очікувалось %v", broken, want)
+	}
+	if !f.requested("https://example.com/preview/missing.png") || f.requested("https://example.com/preview/preview/missing.png") {
+		t.Errorf("ресурс завантажено не з /preview/ або переписано двічі")
+	}
+	if page.Resources.Requests != 5 {
+		t.Errorf("requests = %d, очікувалось 5", page.Resources.Requests)
+	}
+}
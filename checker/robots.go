@@ -0,0 +1,155 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+
+	"sitemap-checker/fetcher"
+	"sitemap-checker/logger"
+	"sitemap-checker/robots"
+)
+
+// RobotsReport містить результати перевірки robots.txt одного хоста
+type RobotsReport struct {
+	Host       string          `json:"host"`
+	URL        string          `json:"url"`
+	StatusCode int             `json:"status_code,omitempty"`
+	Error      string          `json:"error,omitempty"`
+	Access     robots.Access   `json:"access"`
+	Sitemaps   []SitemapStatus `json:"sitemaps"`
+	Issues     []robots.Issue  `json:"issues"`
+}
+
+// SitemapStatus — результат перевірки доступності директиви Sitemap
+type SitemapStatus struct {
+	URL        string `json:"url"`
+	Line       int    `json:"line"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// hostRobots — robots.txt хоста, завантажений один раз за запуск
+type hostRobots struct {
+	once   sync.Once
+	robots *robots.Robots
+	report RobotsReport
+}
+
+// CheckRobotsTxt перевіряє, чи сторінка дозволена в robots.txt, і повертає
+// правило, яке визначило результат
+func (c *Checker) CheckRobotsTxt(ctx context.Context, pageURL string) robots.Result {
+	r := c.robotsFor(ctx, pageURL)
+	if r == nil {
+		return robots.Result{Allowed: true}
+	}
+
+	// Перевіряємо, чи сторінка дозволена
+	result := r.Test(c.cfg.UserAgent, pageURL)
+	if !result.Allowed {
+		if result.Rule != nil {
+			logger.Error("сторінка заблокована в robots.txt: %s (рядок %d: Disallow: %s)", pageURL, result.Rule.Line, result.Rule.Pattern)
+		} else {
+			logger.Error("сторінка заблокована: robots.txt недоступний через помилку сервера: %s", pageURL)
+		}
+	}
+
+	return result
+}
+
+// robotsFor повертає розібраний robots.txt хоста сторінки, завантажуючи його
+// при першому зверненні
+func (c *Checker) robotsFor(ctx context.Context, pageURL string) *robots.Robots {
+	robotsURL, err := robots.URLFor(pageURL)
+	if err != nil {
+		logger.Error("помилка при визначенні адреси robots.txt: %v", err)
+		return nil
+	}
+
+	c.robotsMutex.Lock()
+	host, ok := c.robotsHosts[robotsURL]
+	if !ok {
+		host = &hostRobots{}
+		c.robotsHosts[robotsURL] = host
+	}
+	c.robotsMutex.Unlock()
+
+	host.once.Do(func() {
+		host.robots, host.report = c.loadRobots(ctx, robotsURL)
+	})
+	return host.robots
+}
+
+// loadRobots завантажує robots.txt, трактує відповідь згідно з RFC 9309
+// та готує звіт для хоста
+func (c *Checker) loadRobots(ctx context.Context, robotsURL string) (*robots.Robots, RobotsReport) {
+	u, _ := url.Parse(robotsURL)
+	report := RobotsReport{Host: u.Host, URL: robotsURL}
+
+	var r *robots.Robots
+	statusCode, body, err := c.fetcher.FetchRobotsTxt(ctx, robotsURL)
+	switch {
+	case errors.Is(err, fetcher.ErrTooManyRedirects):
+		// Після п'яти редіректів robots.txt вважається недоступним
+		logger.Error("robots.txt недоступний: %v", err)
+		report.Error = err.Error()
+		r = robots.FromResponse(http.StatusMovedPermanently, nil)
+	case err != nil:
+		logger.Error("помилка при завантаженні robots.txt: %v", err)
+		report.Error = err.Error()
+		r = robots.Unreachable()
+	default:
+		report.StatusCode = statusCode
+		r = robots.FromResponse(statusCode, body)
+	}
+
+	report.Access = r.Access
+	report.Issues = append(make([]robots.Issue, 0), r.Issues...)
+	report.Sitemaps = make([]SitemapStatus, 0, len(r.Sitemaps))
+
+	// Перевіряємо доступність sitemap, оголошених у robots.txt
+	for _, sitemap := range r.Sitemaps {
+		status := SitemapStatus{URL: sitemap.URL, Line: sitemap.Line}
+		resp, err := c.fetcher.Fetch(ctx, &fetcher.Request{URL: c.cfg.Rewrites.Apply(sitemap.URL)})
+		switch {
+		case err != nil:
+			status.Error = err.Error()
+		default:
+			status.StatusCode = resp.StatusCode
+		}
+
+		if err != nil || resp.StatusCode != http.StatusOK {
+			report.Issues = append(report.Issues, robots.Issue{
+				Line:    sitemap.Line,
+				Type:    robots.IssueUnreachableSitemap,
+				Message: fmt.Sprintf("sitemap недоступний: %s", sitemap.URL),
+			})
+		}
+		report.Sitemaps = append(report.Sitemaps, status)
+	}
+
+	for _, issue := range report.Issues {
+		logger.Error("robots.txt %s, рядок %d: %s", robotsURL, issue.Line, issue.Message)
+	}
+
+	return r, report
+}
+
+// robotsReports повертає звіти robots.txt за всіма перевіреними хостами
+func (c *Checker) robotsReports() []RobotsReport {
+	c.robotsMutex.Lock()
+	defer c.robotsMutex.Unlock()
+
+	reports := make([]RobotsReport, 0, len(c.robotsHosts))
+	for _, host := range c.robotsHosts {
+		if host.robots != nil {
+			reports = append(reports, host.report)
+		}
+	}
+	sort.Slice(reports, func(i, j int) bool { return reports[i].URL < reports[j].URL })
+	return reports
+}
@@ -0,0 +1,251 @@
+package checker
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Типи знахідок щодо заголовків безпеки
+const (
+	FindingSecurityHSTS               = "security_hsts"
+	FindingSecurityCSP                = "security_csp"
+	FindingSecurityContentTypeOptions = "security_x_content_type_options"
+	FindingSecurityFrameOptions       = "security_frame_options"
+	FindingSecurityReferrerPolicy     = "security_referrer_policy"
+	FindingSecurityPermissionsPolicy  = "security_permissions_policy"
+)
+
+// securityHeaders — заголовки відповіді, що записуються для аудиту
+var securityHeaders = []string{
+	"Strict-Transport-Security",
+	"Content-Security-Policy",
+	"Content-Security-Policy-Report-Only",
+	"X-Content-Type-Options",
+	"X-Frame-Options",
+	"Referrer-Policy",
+	"Permissions-Policy",
+}
+
+// hstsPreloadMaxAge — мінімальний max-age для включення до списку HSTS preload (1 рік)
+const hstsPreloadMaxAge = 31536000
+
+// HSTS — розібраний заголовок Strict-Transport-Security
+type HSTS struct {
+	MaxAge            int  `json:"max_age"`
+	IncludeSubDomains bool `json:"include_subdomains"`
+	Preload           bool `json:"preload"`
+	PreloadEligible   bool `json:"preload_eligible"` // max-age від року, includeSubDomains та preload
+}
+
+// SecurityHostSummary — підсумок аудиту заголовків безпеки для одного хоста
+type SecurityHostSummary struct {
+	Host                string         `json:"host"`
+	Pages               int            `json:"pages"`
+	Headers             map[string]int `json:"headers"`    // Кількість сторінок з кожним заголовком
+	Deviations          map[string]int `json:"deviations"` // Кількість сторінок з кожним типом знахідки
+	HSTSPreloadEligible bool           `json:"hsts_preload_eligible"`
+}
+
+// checkSecurityHeaders записує заголовки безпеки сторінки та перевіряє їхні значення
+func (c *Checker) checkSecurityHeaders(ctx context.Context, page *Page, result *PageResult) {
+	if !c.cfg.CheckSecurityHeaders || page.Response.StatusCode != http.StatusOK {
+		return
+	}
+	header := page.Response.Header
+
+	result.SecurityHeaders = make(map[string]string)
+	for _, name := range securityHeaders {
+		if values := header.Values(name); len(values) > 0 {
+			result.SecurityHeaders[name] = strings.Join(values, ", ")
+		}
+	}
+
+	if u, err := url.Parse(page.Response.URL); err == nil && u.Scheme == "https" {
+		checkHSTS(header.Get("Strict-Transport-Security"), result)
+	}
+	csp := parseCSP(header.Values("Content-Security-Policy"))
+	checkCSP(csp, header.Get("Content-Security-Policy-Report-Only") != "", result)
+
+	if v := strings.TrimSpace(header.Get("X-Content-Type-Options")); !strings.EqualFold(v, "nosniff") {
+		if v == "" {
+			result.addFinding(FindingSecurityContentTypeOptions, SeverityWarning, "", "відсутній заголовок X-Content-Type-Options: nosniff")
+		} else {
+			result.addFinding(FindingSecurityContentTypeOptions, SeverityWarning, "", "некоректне значення X-Content-Type-Options: %q", v)
+		}
+	}
+
+	frameOptions := strings.ToUpper(strings.TrimSpace(header.Get("X-Frame-Options")))
+	_, frameAncestors := csp["frame-ancestors"]
+	switch {
+	case frameOptions == "" && !frameAncestors:
+		result.addFinding(FindingSecurityFrameOptions, SeverityWarning, "", "немає захисту від вбудовування у фрейми: X-Frame-Options або frame-ancestors у CSP")
+	case frameOptions != "" && frameOptions != "DENY" && frameOptions != "SAMEORIGIN" && !frameAncestors:
+		result.addFinding(FindingSecurityFrameOptions, SeverityWarning, "", "некоректне значення X-Frame-Options: %q", frameOptions)
+	}
+
+	referrerPolicy := strings.ToLower(header.Get("Referrer-Policy"))
+	switch {
+	case strings.TrimSpace(referrerPolicy) == "":
+		result.addFinding(FindingSecurityReferrerPolicy, SeverityInfo, "", "відсутній заголовок Referrer-Policy")
+	case strings.Contains(referrerPolicy, "unsafe-url"):
+		result.addFinding(FindingSecurityReferrerPolicy, SeverityWarning, "", "Referrer-Policy unsafe-url передає повну адресу сторінки іншим сайтам")
+	}
+
+	if strings.TrimSpace(header.Get("Permissions-Policy")) == "" {
+		result.addFinding(FindingSecurityPermissionsPolicy, SeverityInfo, "", "відсутній заголовок Permissions-Policy")
+	}
+}
+
+// checkHSTS розбирає Strict-Transport-Security та перевіряє придатність до preload
+func checkHSTS(value string, result *PageResult) {
+	if strings.TrimSpace(value) == "" {
+		result.addFinding(FindingSecurityHSTS, SeverityWarning, "", "відсутній заголовок Strict-Transport-Security")
+		return
+	}
+
+	hsts := &HSTS{MaxAge: -1}
+	for _, directive := range strings.Split(value, ";") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(directive), "=")
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "max-age":
+			if n, err := strconv.Atoi(strings.Trim(strings.TrimSpace(arg), `"`)); err == nil && n >= 0 {
+				hsts.MaxAge = n
+			}
+		case "includesubdomains":
+			hsts.IncludeSubDomains = true
+		case "preload":
+			hsts.Preload = true
+		}
+	}
+	if hsts.MaxAge < 0 {
+		result.addFinding(FindingSecurityHSTS, SeverityWarning, "", "Strict-Transport-Security без коректного max-age: %q", value)
+		return
+	}
+	hsts.PreloadEligible = hsts.MaxAge >= hstsPreloadMaxAge && hsts.IncludeSubDomains && hsts.Preload
+	result.HSTS = hsts
+
+	switch {
+	case hsts.MaxAge == 0:
+		result.addFinding(FindingSecurityHSTS, SeverityWarning, "", "Strict-Transport-Security з max-age=0 вимикає HSTS")
+	case hsts.MaxAge < hstsPreloadMaxAge:
+		result.addFinding(FindingSecurityHSTS, SeverityInfo, "", "max-age у Strict-Transport-Security менший за рік: %d", hsts.MaxAge)
+	case hsts.Preload && !hsts.PreloadEligible:
+		result.addFinding(FindingSecurityHSTS, SeverityWarning, "", "Strict-Transport-Security з preload без includeSubDomains не приймається до списку preload")
+	}
+}
+
+// parseCSP розбирає політику Content-Security-Policy на директиви та їхні джерела
+func parseCSP(values []string) map[string][]string {
+	policy := make(map[string][]string)
+	for _, value := range values {
+		for _, directive := range strings.Split(value, ";") {
+			fields := strings.Fields(strings.ToLower(directive))
+			if len(fields) == 0 {
+				continue
+			}
+			// Діє перша директива з однаковою назвою
+			if _, exists := policy[fields[0]]; !exists {
+				policy[fields[0]] = fields[1:]
+			}
+		}
+	}
+	return policy
+}
+
+// checkCSP перевіряє наявність Content-Security-Policy та очевидно небезпечні джерела скриптів
+func checkCSP(policy map[string][]string, reportOnly bool, result *PageResult) {
+	if len(policy) == 0 {
+		if reportOnly {
+			result.addFinding(FindingSecurityCSP, SeverityWarning, "", "Content-Security-Policy лише в режимі Report-Only і не застосовується")
+		} else {
+			result.addFinding(FindingSecurityCSP, SeverityWarning, "", "відсутній заголовок Content-Security-Policy")
+		}
+		return
+	}
+
+	// Для скриптів діє script-src, а за його відсутності — default-src
+	directive := "script-src"
+	sources, ok := policy[directive]
+	if !ok {
+		directive = "default-src"
+		sources, ok = policy[directive]
+	}
+	if !ok {
+		result.addFinding(FindingSecurityCSP, SeverityWarning, "", "Content-Security-Policy не обмежує скрипти: немає script-src чи default-src")
+		return
+	}
+
+	// 'unsafe-inline' ігнорується браузерами, якщо вказано nonce або hash, а з
+	// 'strict-dynamic' ігноруються також адреси та схеми: так сувора політика
+	// лишається сумісною зі старими браузерами
+	nonceOrHash, strictDynamic := false, false
+	for _, source := range sources {
+		switch {
+		case strings.HasPrefix(source, "'nonce-"), strings.HasPrefix(source, "'sha256-"), strings.HasPrefix(source, "'sha384-"), strings.HasPrefix(source, "'sha512-"):
+			nonceOrHash = true
+		case source == "'strict-dynamic'":
+			strictDynamic = true
+		}
+	}
+	for _, source := range sources {
+		switch {
+		case source == "'unsafe-inline'" && !nonceOrHash && !strictDynamic,
+			source == "'unsafe-eval'",
+			(source == "*" || source == "data:" || source == "http:" || source == "https:") && !strictDynamic:
+			result.addFinding(FindingSecurityCSP, SeverityWarning, "", "небезпечне джерело %s у %s Content-Security-Policy", source, directive)
+		}
+	}
+}
+
+// reportSecurity підсумовує аудит заголовків безпеки за хостами
+func (c *Checker) reportSecurity(report *Report) {
+	report.Security = make([]SecurityHostSummary, 0)
+
+	hosts := make(map[string]*SecurityHostSummary)
+	var order []string
+	for _, page := range report.Pages {
+		if page.SecurityHeaders == nil {
+			continue
+		}
+		u, err := url.Parse(page.URL)
+		if err != nil {
+			continue
+		}
+
+		summary, ok := hosts[u.Host]
+		if !ok {
+			summary = &SecurityHostSummary{
+				Host:                u.Host,
+				Headers:             make(map[string]int),
+				Deviations:          make(map[string]int),
+				HSTSPreloadEligible: true,
+			}
+			hosts[u.Host] = summary
+			order = append(order, u.Host)
+		}
+
+		summary.Pages++
+		for name := range page.SecurityHeaders {
+			summary.Headers[name]++
+		}
+		seen := make(map[string]bool)
+		for _, finding := range page.Findings {
+			if strings.HasPrefix(finding.Type, "security_") && !seen[finding.Type] {
+				seen[finding.Type] = true
+				summary.Deviations[finding.Type]++
+			}
+		}
+		if page.HSTS == nil || !page.HSTS.PreloadEligible {
+			summary.HSTSPreloadEligible = false
+		}
+	}
+
+	sort.Strings(order)
+	for _, host := range order {
+		report.Security = append(report.Security, *hosts[host])
+	}
+}
@@ -0,0 +1,115 @@
+package checker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// findingMessages повертає повідомлення знахідок заданого типу
+func findingMessages(result *PageResult, findingType string) []string {
+	var messages []string
+	for _, finding := range result.Findings {
+		if finding.Type == findingType {
+			messages = append(messages, finding.Message)
+		}
+	}
+	return messages
+}
+
+func TestCheckHSTS(t *testing.T) {
+	tests := []struct {
+		value    string
+		want     *HSTS
+		finding  bool
+		severity string
+	}{
+		{"", nil, true, SeverityWarning},
+		{"max-age=63072000; includeSubDomains; preload", &HSTS{MaxAge: 63072000, IncludeSubDomains: true, Preload: true, PreloadEligible: true}, false, ""},
+		{`MAX-AGE="31536000";includesubdomains`, &HSTS{MaxAge: 31536000, IncludeSubDomains: true}, false, ""},
+		{"max-age=31536000; preload", &HSTS{MaxAge: 31536000, Preload: true}, true, SeverityWarning},
+		{"max-age=86400; includeSubDomains; preload", &HSTS{MaxAge: 86400, IncludeSubDomains: true, Preload: true}, true, SeverityInfo},
+		{"max-age=0", &HSTS{MaxAge: 0}, true, SeverityWarning},
+		{"max-age=-1; includeSubDomains", nil, true, SeverityWarning},
+		{"includeSubDomains", nil, true, SeverityWarning},
+	}
+
+	for _, tt := range tests {
+		result := &PageResult{}
+		checkHSTS(tt.value, result)
+		if !reflect.DeepEqual(result.HSTS, tt.want) {
+			t.Errorf("checkHSTS(%q): hsts = %+v, очікувалось %+v", tt.value, result.HSTS, tt.want)
+		}
+		if got := hasFinding(result, FindingSecurityHSTS); got != tt.finding {
+			t.Errorf("checkHSTS(%q): знахідка = %v, очікувалось %v", tt.value, got, tt.finding)
+		}
+		if tt.finding && len(result.Findings) > 0 && result.Findings[0].Severity != tt.severity {
+			t.Errorf("checkHSTS(%q): серйозність %s, очікувалось %s", tt.value, result.Findings[0].Severity, tt.severity)
+		}
+	}
+}
+
+func TestParseCSP(t *testing.T) {
+	tests := []struct {
+		values []string
+		want   map[string][]string
+	}{
+		{nil, map[string][]string{}},
+		{
+			[]string{"default-src 'self'; Script-Src 'self' https://cdn.example.com ;; upgrade-insecure-requests"},
+			map[string][]string{"default-src": {"'self'"}, "script-src": {"'self'", "https://cdn.example.com"}, "upgrade-insecure-requests": {}},
+		},
+		{
+			// Діє перша директива з однаковою назвою, зокрема з іншого заголовка
+			[]string{"script-src 'self'; script-src *", "script-src 'unsafe-inline'; frame-ancestors 'none'"},
+			map[string][]string{"script-src": {"'self'"}, "frame-ancestors": {"'none'"}},
+		},
+	}
+	for _, tt := range tests {
+		if got := parseCSP(tt.values); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("parseCSP(%q) = %v, очікувалось %v", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestCheckCSP(t *testing.T) {
+	tests := []struct {
+		name       string
+		policy     string
+		reportOnly bool
+		want       []string // Фрагменти повідомлень знахідок
+	}{
+		{"відсутня", "", false, []string{"відсутній заголовок"}},
+		{"лише Report-Only", "", true, []string{"Report-Only"}},
+		{"без script-src і default-src", "img-src 'self'", false, []string{"не обмежує скрипти"}},
+		{"безпечна", "default-src 'self'", false, nil},
+		{"unsafe-inline у default-src", "default-src 'self' 'unsafe-inline'", false, []string{"'unsafe-inline' у default-src"}},
+		{"script-src важливіший за default-src", "default-src *; script-src 'self'", false, nil},
+		{"nonce скасовує unsafe-inline", "script-src 'nonce-abc' 'unsafe-inline'", false, nil},
+		{"hash скасовує unsafe-inline", "script-src 'sha256-abc=' 'unsafe-inline'", false, nil},
+		{"strict-dynamic скасовує unsafe-inline та схеми", "script-src 'nonce-abc' 'strict-dynamic' 'unsafe-inline' https: http:", false, nil},
+		{"unsafe-eval не скасовується", "script-src 'nonce-abc' 'strict-dynamic' 'unsafe-eval'", false, []string{"'unsafe-eval'"}},
+		{"широкі джерела", "script-src * data: https:", false, []string{"* у", "data: у", "https: у"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var values []string
+			if tt.policy != "" {
+				values = []string{tt.policy}
+			}
+			result := &PageResult{}
+			checkCSP(parseCSP(values), tt.reportOnly, result)
+
+			messages := findingMessages(result, FindingSecurityCSP)
+			if len(messages) != len(tt.want) {
+				t.Fatalf("знахідки %q, очікувалось %d", messages, len(tt.want))
+			}
+			for i, fragment := range tt.want {
+				if !strings.Contains(messages[i], fragment) {
+					t.Errorf("знахідка %q не містить %q", messages[i], fragment)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"image"
+	_ "image/gif"  // Реєстрація декодера GIF
+	_ "image/jpeg" // Реєстрація декодера JPEG
+	_ "image/png"  // Реєстрація декодера PNG
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"sitemap-checker/fetcher"
+	"sitemap-checker/logger"
+)
+
+// Типи знахідок щодо Open Graph та Twitter Card
+const (
+	FindingOGMissing          = "og_missing"
+	FindingOGURLMismatch      = "og_url_mismatch"
+	FindingOGImageInvalid     = "og_image_invalid"
+	FindingOGImageError       = "og_image_error"
+	FindingOGImageStatus      = "og_image_status"
+	FindingOGImageType        = "og_image_type"
+	FindingOGImageTooSmall    = "og_image_too_small"
+	FindingTwitterCardMissing = "twitter_card_missing"
+	FindingTwitterCardInvalid = "twitter_card_invalid"
+)
+
+// requiredOGProperties — обов'язкові властивості Open Graph
+var requiredOGProperties = []string{"og:title", "og:type", "og:image", "og:url"}
+
+// twitterCardTypes — допустимі значення twitter:card
+var twitterCardTypes = []string{"summary", "summary_large_image", "app", "player"}
+
+// Мінімальні розміри зображення для попереднього перегляду
+const (
+	minOGImageWidth  = 200
+	minOGImageHeight = 200
+)
+
+// ImageInfo — результат завантаження зображення
+type ImageInfo struct {
+	URL         string `json:"url"`
+	StatusCode  int    `json:"status_code,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+	Format      string `json:"format,omitempty"` // Формат, визначений декодером
+	Width       int    `json:"width,omitempty"`
+	Height      int    `json:"height,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// imageTarget — зображення, завантажене один раз за запуск
+type imageTarget struct {
+	once sync.Once
+	info ImageInfo
+	err  error
+}
+
+// checkSocial витягує властивості Open Graph і Twitter Card та перевіряє їх
+func (c *Checker) checkSocial(ctx context.Context, page *Page, result *PageResult) {
+	doc := page.Document
+
+	for _, prop := range doc.MetaPrefixed("og:") {
+		if result.OpenGraph == nil {
+			result.OpenGraph = make(map[string]string)
+		}
+		// Для повторюваних властивостей (кілька og:image) зберігаємо першу
+		if _, exists := result.OpenGraph[prop.Key]; !exists {
+			result.OpenGraph[prop.Key] = prop.Val
+		}
+	}
+	for _, prop := range doc.MetaPrefixed("twitter:") {
+		if result.TwitterCard == nil {
+			result.TwitterCard = make(map[string]string)
+		}
+		if _, exists := result.TwitterCard[prop.Key]; !exists {
+			result.TwitterCard[prop.Key] = prop.Val
+		}
+	}
+
+	// Сторінки з помилками та редіректами не показуються в попередньому перегляді
+	if page.Response.StatusCode != http.StatusOK || len(page.Response.Redirects) > 0 {
+		return
+	}
+
+	for _, key := range requiredOGProperties {
+		if result.OpenGraph[key] == "" {
+			result.addFinding(FindingOGMissing, SeverityWarning, "", "відсутня властивість %s", key)
+		}
+	}
+
+	base := documentBase(page.FinalURL, doc)
+
+	// og:url має збігатися з канонічною адресою сторінки
+	if raw := result.OpenGraph["og:url"]; raw != "" {
+		expected := result.CanonicalURL
+		if expected == "" {
+			expected = page.Entry.Loc
+		}
+		if ogURL := resolveURL(base, raw); ogURL == "" || !c.cfg.Rewrites.Equal(ogURL, expected) {
+			result.addFinding(FindingOGURLMismatch, SeverityWarning, raw, "og:url %q не збігається з канонічною адресою %s", raw, expected)
+		}
+	}
+
+	if raw := result.OpenGraph["og:image"]; raw != "" {
+		c.checkOGImage(ctx, base, raw, result)
+	}
+
+	if card, ok := result.TwitterCard["twitter:card"]; !ok {
+		result.addFinding(FindingTwitterCardMissing, SeverityInfo, "", "відсутній twitter:card")
+	} else if !containsString(twitterCardTypes, strings.ToLower(card)) {
+		result.addFinding(FindingTwitterCardInvalid, SeverityWarning, "", "невідомий тип twitter:card: %q", card)
+	}
+}
+
+// checkOGImage перевіряє адресу og:image, а з CHECK_OG_IMAGE завантажує
+// зображення та перевіряє статус, тип і розміри
+func (c *Checker) checkOGImage(ctx context.Context, base *url.URL, raw string, result *PageResult) {
+	imageURL := resolveURL(base, raw)
+	if imageURL == "" {
+		result.addFinding(FindingOGImageInvalid, SeverityError, "", "некоректний og:image: %q", raw)
+		return
+	}
+	if u, err := url.Parse(raw); err == nil && !u.IsAbs() {
+		// Соцмережі не розв'язують відносні адреси
+		result.addFinding(FindingOGImageInvalid, SeverityWarning, imageURL, "og:image має бути абсолютним URL: %q", raw)
+	}
+	if !c.cfg.CheckOGImage {
+		return
+	}
+
+	target := c.imageTarget(ctx, imageURL)
+	info := target.info
+	result.OGImage = &info
+
+	switch {
+	case target.err != nil:
+		result.addFinding(FindingOGImageError, SeverityError, imageURL, "не вдалося завантажити og:image: %v", target.err)
+		return
+	case info.StatusCode != http.StatusOK:
+		result.addFinding(FindingOGImageStatus, SeverityError, imageURL, "og:image повертає статус %d", info.StatusCode)
+		return
+	}
+
+	if !strings.HasPrefix(info.ContentType, "image/") {
+		result.addFinding(FindingOGImageType, SeverityError, imageURL, "og:image має тип %q замість зображення", info.ContentType)
+	} else if info.Format != "" && info.ContentType != "image/"+info.Format {
+		result.addFinding(FindingOGImageType, SeverityWarning, imageURL, "тип og:image %q не відповідає вмісту (%s)", info.ContentType, info.Format)
+	}
+
+	if info.Format != "" && (info.Width < minOGImageWidth || info.Height < minOGImageHeight) {
+		result.addFinding(FindingOGImageTooSmall, SeverityWarning, imageURL, "og:image замалий: %dx%d (мінімум %dx%d)", info.Width, info.Height, minOGImageWidth, minOGImageHeight)
+	}
+}
+
+// imageTarget завантажує зображення один раз за запуск
+func (c *Checker) imageTarget(ctx context.Context, imageURL string) *imageTarget {
+	key := c.cfg.Rewrites.Apply(imageURL)
+
+	c.imageMutex.Lock()
+	target, ok := c.imageTargets[key]
+	if !ok {
+		target = &imageTarget{info: ImageInfo{URL: imageURL}}
+		c.imageTargets[key] = target
+	}
+	c.imageMutex.Unlock()
+
+	target.once.Do(func() {
+		resp, err := c.fetcher.Fetch(ctx, &fetcher.Request{URL: key})
+		if err != nil {
+			logger.Error("помилка при завантаженні зображення %s: %v", imageURL, err)
+			target.err = err
+			target.info.Error = err.Error()
+			return
+		}
+
+		target.info.StatusCode = resp.StatusCode
+		if mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+			target.info.ContentType = mediaType
+		}
+		if resp.StatusCode != http.StatusOK {
+			return
+		}
+
+		// Розміри визначаються для форматів, які підтримує стандартна бібліотека
+		if cfg, format, err := image.DecodeConfig(bytes.NewReader(resp.Body)); err == nil {
+			target.info.Format = format
+			target.info.Width = cfg.Width
+			target.info.Height = cfg.Height
+		}
+	})
+	return target
+}
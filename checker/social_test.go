@@ -0,0 +1,117 @@
+package checker
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"net/http"
+	"testing"
+
+	"sitemap-checker/cache"
+	"sitemap-checker/fetcher"
+	"sitemap-checker/htmldoc"
+	"sitemap-checker/parser"
+)
+
+// pngImage кодує порожнє зображення PNG заданого розміру
+func pngImage(t *testing.T, width, height int) string {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestCheckSocial(t *testing.T) {
+	images := map[string]fakeResponse{
+		"https://example.com/og.png":    {header: http.Header{"Content-Type": {"image/png"}}, body: pngImage(t, 1200, 630)},
+		"https://example.com/small.png": {header: http.Header{"Content-Type": {"image/png"}}, body: pngImage(t, 100, 100)},
+		"https://example.com/wrong.jpg": {header: http.Header{"Content-Type": {"image/jpeg"}}, body: pngImage(t, 1200, 630)},
+		"https://example.com/page.png":  {body: "<html></html>"},
+	}
+	complete := `<meta property="og:title" content="Заголовок"><meta property="og:type" content="website">
+<meta property="og:url" content="https://example.com/page/"><meta name="twitter:card" content="summary_large_image">`
+
+	tests := []struct {
+		name     string
+		head     string
+		env      map[string]string
+		findings map[string]bool
+		fetched  bool
+	}{
+		{
+			"повний набір без завантаження зображення",
+			complete + `<meta property="og:image" content="https://example.com/missing.png">`, nil,
+			map[string]bool{FindingOGMissing: false, FindingOGURLMismatch: false, FindingOGImageStatus: false, FindingTwitterCardMissing: false}, false,
+		},
+		{
+			"недоступне зображення",
+			complete + `<meta property="og:image" content="https://example.com/missing.png">`, map[string]string{"CHECK_OG_IMAGE": "true"},
+			map[string]bool{FindingOGImageStatus: true}, true,
+		},
+		{
+			"коректне зображення",
+			complete + `<meta property="og:image" content="https://example.com/og.png">`, map[string]string{"CHECK_OG_IMAGE": "true"},
+			map[string]bool{FindingOGImageStatus: false, FindingOGImageType: false, FindingOGImageTooSmall: false}, true,
+		},
+		{
+			"замале зображення",
+			complete + `<meta property="og:image" content="https://example.com/small.png">`, map[string]string{"CHECK_OG_IMAGE": "true"},
+			map[string]bool{FindingOGImageTooSmall: true}, true,
+		},
+		{
+			"тип не відповідає вмісту",
+			complete + `<meta property="og:image" content="https://example.com/wrong.jpg">`, map[string]string{"CHECK_OG_IMAGE": "true"},
+			map[string]bool{FindingOGImageType: true}, true,
+		},
+		{
+			"не зображення",
+			complete + `<meta property="og:image" content="https://example.com/page.png">`, map[string]string{"CHECK_OG_IMAGE": "true"},
+			map[string]bool{FindingOGImageType: true}, true,
+		},
+		{
+			"відносна адреса без завантаження",
+			complete + `<meta property="og:image" content="/og.png">`, nil,
+			map[string]bool{FindingOGImageInvalid: true}, false,
+		},
+		{
+			"без og:image і twitter:card",
+			`<meta property="og:title" content="Заголовок">`, nil,
+			map[string]bool{FindingOGMissing: true, FindingTwitterCardMissing: true}, false,
+		},
+		{
+			"og:url інший та невідомий twitter:card",
+			`<meta property="og:url" content="https://example.com/other/"><meta name="twitter:card" content="gallery">`, nil,
+			map[string]bool{FindingOGURLMismatch: true, FindingTwitterCardInvalid: true}, false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := &fakeFetcher{responses: images}
+			c := New(testConfig(t, tt.env), fetcher.NewCachedFetcher(f, cache.NewMemory(10), fetcher.CacheTTL{}))
+			page := &Page{
+				Entry:    parser.URL{Loc: "https://example.com/page/"},
+				FinalURL: "https://example.com/page/",
+				Response: &fetcher.Response{URL: "https://example.com/page/", StatusCode: http.StatusOK},
+				Document: htmldoc.Parse([]byte("<html><head>" + tt.head + "</head><body></body></html>")),
+			}
+			result := &PageResult{}
+			c.checkSocial(context.Background(), page, result)
+
+			for findingType, want := range tt.findings {
+				if got := hasFinding(result, findingType); got != want {
+					t.Errorf("знахідка %s = %v, очікувалось %v", findingType, got, want)
+				}
+			}
+			f.mu.Lock()
+			fetched := len(f.requests) > 0
+			f.mu.Unlock()
+			if fetched != tt.fetched || (result.OGImage != nil) != tt.fetched {
+				t.Errorf("зображення завантажено = %v (og_image %+v), очікувалось %v", fetched, result.OGImage, tt.fetched)
+			}
+		})
+	}
+}
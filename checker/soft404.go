@@ -0,0 +1,200 @@
+package checker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"math"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"sitemap-checker/fetcher"
+	"sitemap-checker/htmldoc"
+	"sitemap-checker/logger"
+	"sitemap-checker/simhash"
+)
+
+// FindingSoft404 — сторінка зі статусом 200 схожа на сторінку «не знайдено»
+const FindingSoft404 = "soft_404"
+
+// Ознаки soft-404 та їхня вага в оцінці впевненості
+const (
+	SignalErrorPageSimilarity = "error_page_similarity" // Вміст схожий на відповідь хоста для неіснуючої адреси
+	SignalTitlePattern        = "title_pattern"         // Заголовок збігається з шаблоном
+	SignalBodyPattern         = "body_pattern"          // Основний текст збігається з шаблоном
+	SignalThinContent         = "thin_content"          // Замало слів в основному тексті
+)
+
+var soft404Weights = map[string]float64{
+	SignalErrorPageSimilarity: 0.6,
+	SignalTitlePattern:        0.4,
+	SignalBodyPattern:         0.3,
+	SignalThinContent:         0.2,
+}
+
+// soft404MinConfidence — мінімальна впевненість, з якою сторінка вважається soft-404;
+// одна лише мала кількість слів чи фраза в тексті її не досягає
+const soft404MinConfidence = 0.4
+
+// simhashMinWords — мінімальна кількість слів для порівняння зі сторінкою помилки:
+// для коротшого тексту відбиток SimHash не має шинглів
+const simhashMinWords = 3
+
+// Soft404 — ознаки того, що сторінка є заглушкою «не знайдено»
+type Soft404 struct {
+	Confidence float64  `json:"confidence"` // Від 0 до 1
+	Signals    []string `json:"signals"`
+	Similarity float64  `json:"similarity,omitempty"` // Схожість із відповіддю для неіснуючої адреси
+}
+
+// Soft404Page — сторінка, підозріла на soft-404
+type Soft404Page struct {
+	URL string `json:"url"`
+	Soft404
+}
+
+// Soft404Probe — відповідь хоста на запит випадкової неіснуючої адреси
+type Soft404Probe struct {
+	Host       string `json:"host"`
+	URL        string `json:"url"`
+	StatusCode int    `json:"status_code,omitempty"`
+	WordCount  int    `json:"word_count"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Soft404Report — підсумок пошуку soft-404
+type Soft404Report struct {
+	Probes []Soft404Probe `json:"probes"`
+	Pages  []Soft404Page  `json:"pages"`
+}
+
+// soft404Host — відбиток відповіді хоста для неіснуючої адреси, отриманий один раз за запуск
+type soft404Host struct {
+	once        sync.Once
+	probe       Soft404Probe
+	fingerprint uint64
+	words       int
+}
+
+// checkSoft404 оцінює, чи є сторінка зі статусом 200 заглушкою «не знайдено»
+func (c *Checker) checkSoft404(ctx context.Context, page *Page, result *PageResult) {
+	if !c.cfg.CheckSoft404 || page.Response.StatusCode != http.StatusOK || len(page.Response.Redirects) > 0 {
+		return
+	}
+
+	soft := &Soft404{}
+	add := func(signal string) {
+		soft.Signals = append(soft.Signals, signal)
+		soft.Confidence += soft404Weights[signal]
+	}
+
+	// Головна сторінка часто збігається з відповіддю сайтів, що віддають її замість 404;
+	// її адреса визначається за оригінальним URL і переписується один раз
+	if root := c.cfg.Rewrites.Apply(siteRoot(page.Entry.Loc)); root != "" && root != page.Response.URL {
+		if host := c.soft404Host(ctx, root); host.words >= simhashMinWords && result.WordCount > 0 {
+			soft.Similarity = math.Round(simhash.Similarity(host.fingerprint, result.fingerprint)*1000) / 1000
+			if soft.Similarity >= c.cfg.Soft404Similarity {
+				add(SignalErrorPageSimilarity)
+			}
+		}
+	}
+	if matchesAny(c.cfg.Soft404TitlePatterns, page.Document.Title) {
+		add(SignalTitlePattern)
+	}
+	if matchesAny(c.cfg.Soft404BodyPatterns, page.Document.MainText()) {
+		add(SignalBodyPattern)
+	}
+	if result.WordCount < c.cfg.Soft404MinWords {
+		add(SignalThinContent)
+	}
+
+	soft.Confidence = math.Round(math.Min(soft.Confidence, 1)*100) / 100
+	if soft.Confidence < soft404MinConfidence {
+		return
+	}
+	result.Soft404 = soft
+	result.addFinding(FindingSoft404, SeverityWarning, "", "сторінка схожа на «не знайдено» зі статусом 200 (впевненість %.2f: %s)", soft.Confidence, strings.Join(soft.Signals, ", "))
+}
+
+// soft404Host запитує випадкову неіснуючу адресу хоста один раз за запуск
+func (c *Checker) soft404Host(ctx context.Context, root string) *soft404Host {
+	c.soft404Mutex.Lock()
+	host, ok := c.soft404Hosts[root]
+	if !ok {
+		host = &soft404Host{}
+		c.soft404Hosts[root] = host
+	}
+	c.soft404Mutex.Unlock()
+
+	host.once.Do(func() {
+		probeURL := root + randomPath()
+		host.probe = Soft404Probe{URL: probeURL}
+		if u, err := url.Parse(root); err == nil {
+			host.probe.Host = u.Host
+		}
+
+		resp, err := c.fetcher.Fetch(ctx, &fetcher.Request{URL: probeURL})
+		if err != nil {
+			logger.Error("помилка при запиті неіснуючої адреси %s: %v", probeURL, err)
+			host.probe.Error = err.Error()
+			return
+		}
+		host.probe.StatusCode = resp.StatusCode
+		if !isHTML(resp.Header.Get("Content-Type")) {
+			return
+		}
+
+		words := simhash.Words(htmldoc.Parse(resp.Body).MainText())
+		host.words = len(words)
+		host.probe.WordCount = len(words)
+		host.fingerprint = simhash.Fingerprint(words)
+	})
+	return host
+}
+
+// randomPath повертає шлях, якого гарантовано немає на сайті
+func randomPath() string {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "sitemap-checker-404"
+	}
+	return "sitemap-checker-404-" + hex.EncodeToString(b)
+}
+
+// matchesAny перевіряє, чи збігається текст хоча б з одним шаблоном
+func matchesAny(patterns []*regexp.Regexp, text string) bool {
+	for _, re := range patterns {
+		if re.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// reportSoft404 перелічує сторінки, підозрілі на soft-404, та відповіді хостів для неіснуючих адрес
+func (c *Checker) reportSoft404(report *Report) {
+	report.Soft404 = &Soft404Report{Probes: make([]Soft404Probe, 0), Pages: make([]Soft404Page, 0)}
+
+	c.soft404Mutex.Lock()
+	for _, host := range c.soft404Hosts {
+		report.Soft404.Probes = append(report.Soft404.Probes, host.probe)
+	}
+	c.soft404Mutex.Unlock()
+	sort.Slice(report.Soft404.Probes, func(i, j int) bool {
+		return report.Soft404.Probes[i].Host < report.Soft404.Probes[j].Host
+	})
+
+	for _, page := range report.Pages {
+		if page.Soft404 != nil {
+			report.Soft404.Pages = append(report.Soft404.Pages, Soft404Page{URL: page.URL, Soft404: *page.Soft404})
+		}
+	}
+	sort.Slice(report.Soft404.Pages, func(i, j int) bool {
+		a, b := report.Soft404.Pages[i], report.Soft404.Pages[j]
+		return a.Confidence > b.Confidence || a.Confidence == b.Confidence && a.URL < b.URL
+	})
+}
@@ -0,0 +1,127 @@
+package checker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"sitemap-checker/cache"
+	"sitemap-checker/fetcher"
+	"sitemap-checker/htmldoc"
+	"sitemap-checker/parser"
+)
+
+// errorPageFetcher віддає errorPage зі статусом 200 на запити неіснуючих адрес,
+// як сайти із soft-404
+type errorPageFetcher struct {
+	*fakeFetcher
+	errorPage string
+}
+
+func (f *errorPageFetcher) Fetch(ctx context.Context, req *fetcher.Request) (*fetcher.Response, error) {
+	if strings.Contains(req.URL, "sitemap-checker-404-") {
+		f.fakeFetcher.Fetch(ctx, req) // Запит записується для перевірки
+		header := http.Header{"Content-Type": {"text/html; charset=utf-8"}}
+		return &fetcher.Response{URL: req.URL, StatusCode: http.StatusOK, Header: header, Body: []byte(f.errorPage)}, nil
+	}
+	return f.fakeFetcher.Fetch(ctx, req)
+}
+
+// wordsText повертає текст з n різних слів
+func wordsText(prefix string, n int) string {
+	words := make([]string, n)
+	for i := range words {
+		words[i] = fmt.Sprintf("%s%d", prefix, i)
+	}
+	return strings.Join(words, " ")
+}
+
+func TestCheckSoft404(t *testing.T) {
+	errorText := "Ой, щось пішло не так. " + wordsText("помилка", 60)
+	errorPage := "<html><head><title>Магазин</title></head><body><main>" + errorText + "</main></body></html>"
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		url     string
+		status  int
+		title   string
+		body    string
+		signals []string // nil — сторінка не є soft-404
+	}{
+		{"звичайна сторінка", nil, "https://example.com/a/", http.StatusOK, "Каталог товарів", wordsText("товар", 80), nil},
+		{"заголовок «не знайдено» і мало слів", nil, "https://example.com/a/", http.StatusOK, "404 — Сторінку не знайдено", "Вибачте", []string{SignalTitlePattern, SignalThinContent}},
+		{"лише фраза в довгому тексті", nil, "https://example.com/a/", http.StatusOK, "Новини", "Товар більше не доступний. " + wordsText("новина", 80), nil},
+		{"фраза та мало слів", nil, "https://example.com/a/", http.StatusOK, "Товар", "Товар не знайдено", []string{SignalBodyPattern, SignalThinContent}},
+		{"схожа на сторінку помилки", nil, "https://example.com/a/", http.StatusOK, "Магазин", errorText, []string{SignalErrorPageSimilarity}},
+		{"головна сторінка не порівнюється", nil, "https://example.com/", http.StatusOK, "Магазин", errorText, nil},
+		{"статус не 200", nil, "https://example.com/a/", http.StatusNotFound, "Сторінку не знайдено", "Вибачте", nil},
+		{"пошук вимкнено", map[string]string{"CHECK_SOFT404": "false"}, "https://example.com/a/", http.StatusOK, "Сторінку не знайдено", "Вибачте", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := map[string]string{"CHECK_SOFT404": "true"}
+			for key, value := range tt.env {
+				env[key] = value
+			}
+			f := &errorPageFetcher{fakeFetcher: &fakeFetcher{}, errorPage: errorPage}
+			c := New(testConfig(t, env), fetcher.NewCachedFetcher(f, cache.NewMemory(10), fetcher.CacheTTL{}))
+
+			page := &Page{
+				Entry:    parser.URL{Loc: tt.url},
+				FinalURL: tt.url,
+				Response: &fetcher.Response{URL: tt.url, StatusCode: tt.status},
+				Document: htmldoc.Parse([]byte("<html><head><title>" + tt.title + "</title></head><body><main>" + tt.body + "</main></body></html>")),
+			}
+			result := &PageResult{}
+			c.checkContent(context.Background(), page, result)
+			c.checkSoft404(context.Background(), page, result)
+
+			var signals []string
+			if result.Soft404 != nil {
+				signals = result.Soft404.Signals
+			}
+			if !reflect.DeepEqual(signals, tt.signals) {
+				t.Errorf("soft_404 = %+v, очікувались ознаки %v", result.Soft404, tt.signals)
+			}
+			if hasFinding(result, FindingSoft404) != (tt.signals != nil) {
+				t.Errorf("знахідка soft_404 = %v", hasFinding(result, FindingSoft404))
+			}
+		})
+	}
+}
+
+// TestReportSoft404Probe перевіряє, що неіснуюча адреса хоста запитується один
+// раз і з правилами переписування
+func TestReportSoft404Probe(t *testing.T) {
+	f := &errorPageFetcher{fakeFetcher: &fakeFetcher{}, errorPage: "<html><body>Не знайдено</body></html>"}
+	c := New(testConfig(t, map[string]string{"CHECK_SOFT404": "true", "REWRITE_HOSTS": "example.com=staging.local"}),
+		fetcher.NewCachedFetcher(f, cache.NewMemory(10), fetcher.CacheTTL{}))
+
+	for _, loc := range []string{"https://example.com/a/", "https://example.com/b/"} {
+		page := &Page{
+			Entry:    parser.URL{Loc: loc},
+			FinalURL: loc,
+			Response: &fetcher.Response{URL: c.cfg.Rewrites.Apply(loc), StatusCode: http.StatusOK},
+			Document: htmldoc.Parse([]byte("<html><body><main>" + wordsText("слово", 80) + "</main></body></html>")),
+		}
+		c.checkSoft404(context.Background(), page, &PageResult{})
+	}
+
+	report := &Report{}
+	c.reportSoft404(report)
+	if len(report.Soft404.Probes) != 1 {
+		t.Fatalf("probes = %+v, очікувався один запит", report.Soft404.Probes)
+	}
+	probe := report.Soft404.Probes[0]
+	if !strings.HasPrefix(probe.URL, "https://staging.local/sitemap-checker-404-") || probe.StatusCode != http.StatusOK {
+		t.Errorf("probe = %+v, очікувався запит до staging.local зі статусом 200", probe)
+	}
+	if len(f.requests) != 1 {
+		t.Errorf("неіснуючу адресу запитано %d разів", len(f.requests))
+	}
+}
@@ -0,0 +1,69 @@
+package checker
+
+import (
+	"context"
+	"errors"
+	"mime"
+	"strings"
+
+	"sitemap-checker/structured"
+)
+
+// Типи знахідок щодо структурованих даних
+const (
+	FindingStructuredDataSyntax      = "structured_data_syntax"
+	FindingStructuredDataInvalid     = "structured_data_invalid"
+	FindingStructuredDataRequired    = "structured_data_required"
+	FindingStructuredDataRecommended = "structured_data_recommended"
+)
+
+// StructuredDataBlock — блок <script type="application/ld+json"> сторінки
+type StructuredDataBlock struct {
+	Line     int                 `json:"line"`            // Рядок тегу <script> у HTML
+	Error    string              `json:"error,omitempty"` // Помилка синтаксису JSON
+	Entities []structured.Entity `json:"entities,omitempty"`
+}
+
+// checkStructuredData розбирає блоки JSON-LD та перевіряє сутності за правилами schema.org
+func (c *Checker) checkStructuredData(ctx context.Context, page *Page, result *PageResult) {
+	doc := page.Document
+
+	for _, el := range doc.Find("script") {
+		mediaType, _, err := mime.ParseMediaType(el.Attr("type"))
+		if err != nil || mediaType != "application/ld+json" {
+			continue
+		}
+
+		line := strings.Count(doc.Source[:el.Start], "\n") + 1
+		block := StructuredDataBlock{Line: line}
+
+		entities, err := structured.Parse(el.Text)
+		if err != nil {
+			block.Error = err.Error()
+			var syntaxErr *structured.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				// Номер рядка в блоці переводимо в номер рядка HTML
+				line += syntaxErr.Line - 1
+				block.Error = syntaxErr.Err.Error()
+			}
+			result.addFinding(FindingStructuredDataSyntax, SeverityError, "", "некоректний JSON-LD (рядок %d): %s", line, block.Error)
+			result.StructuredData = append(result.StructuredData, block)
+			continue
+		}
+		block.Entities = entities
+
+		for _, entity := range entities {
+			for _, issue := range entity.Issues {
+				switch issue.Type {
+				case structured.IssueMissingRequired:
+					result.addFinding(FindingStructuredDataRequired, SeverityError, "", "JSON-LD %s: %s", entity.Path, issue.Message)
+				case structured.IssueMissingRecommended:
+					result.addFinding(FindingStructuredDataRecommended, SeverityInfo, "", "JSON-LD %s: %s", entity.Path, issue.Message)
+				default:
+					result.addFinding(FindingStructuredDataInvalid, SeverityWarning, "", "JSON-LD %s: %s", entity.Path, issue.Message)
+				}
+			}
+		}
+		result.StructuredData = append(result.StructuredData, block)
+	}
+}
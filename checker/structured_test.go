@@ -0,0 +1,60 @@
+package checker
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"sitemap-checker/htmldoc"
+)
+
+func TestCheckStructuredData(t *testing.T) {
+	html := `<html><head>
+<script type="application/ld+json">{"@context": "https://schema.org", "@type": "BreadcrumbList", "itemListElement": [{"@type": "ListItem", "position": 1}]}</script>
+<script type="text/javascript">var x = {"@type": "Product"};</script>
+</head><body>
+<script type="Application/LD+JSON; charset=utf-8">
+{
+  "@type": "Answer",
+  "text": "Так"
+</script>
+<script type="application/ld+json">{"@context": "https://schema.org", "@type": "Organization", "name": "X"}</script>
+</body></html>`
+
+	result := &PageResult{}
+	page := &Page{Document: htmldoc.Parse([]byte(html))}
+	(&Checker{}).checkStructuredData(context.Background(), page, result)
+
+	if len(result.StructuredData) != 3 {
+		t.Fatalf("блоків %d, очікувалось 3 (скрипт JavaScript пропускається): %+v", len(result.StructuredData), result.StructuredData)
+	}
+	wantLines := []int{2, 5, 10}
+	for i, block := range result.StructuredData {
+		if block.Line != wantLines[i] {
+			t.Errorf("блок %d: рядок %d, очікувався %d", i, block.Line, wantLines[i])
+		}
+	}
+	if result.StructuredData[1].Error == "" || len(result.StructuredData[1].Entities) != 0 {
+		t.Errorf("некоректний блок = %+v, очікувалась помилка синтаксису", result.StructuredData[1])
+	}
+
+	tests := []struct {
+		findingType, severity, message string
+	}{
+		{FindingStructuredDataRequired, SeverityError, "JSON-LD $.itemListElement[0]: ListItem: відсутня обов'язкова властивість name або item"},
+		{FindingStructuredDataRecommended, SeverityInfo, "JSON-LD $: Organization: відсутня рекомендована властивість url"},
+		// Рядок помилки — у HTML, а не в блоці: незакритий об'єкт закінчується перед </script> на рядку 9
+		{FindingStructuredDataSyntax, SeverityError, "некоректний JSON-LD (рядок 9)"},
+	}
+	for _, tt := range tests {
+		found := false
+		for _, finding := range result.Findings {
+			if finding.Type == tt.findingType && finding.Severity == tt.severity && strings.HasPrefix(finding.Message, tt.message) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("немає знахідки %s (%s) %q серед %+v", tt.findingType, tt.severity, tt.message, result.Findings)
+		}
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"log"
+	"sitemap-checker/cache"
 	"sitemap-checker/checker"
 	"sitemap-checker/config"
 	"sitemap-checker/fetcher"
@@ -22,16 +24,63 @@ func main() {
 		return
 	}
 
-	// Ініціалізація Redis
-	fetcher.InitRedis(cfg.RedisURL)
-	defer fetcher.CleanupTempFiles() // Видаляємо тимчасові файли після завершення
+	// Джерело сторінок (HTTP, локальний каталог або архів)
+	f, err := fetcher.New(fetcher.Options{
+		Backend:      cfg.FetchBackend,
+		Source:       cfg.FetchSource,
+		ProxyURL:     cfg.ProxyURL,
+		DNSOverrides: cfg.DNSOverrides,
+		Timeout:      cfg.Timeout,
+		MaxRedirects: cfg.MaxRedirects,
+		UserAgent:    cfg.UserAgent,
+	})
+	if err != nil {
+		logger.Error("Помилка при налаштуванні джерела сторінок: %v", err)
+		return
+	}
+
+	// Ініціалізація кешу (Redis, диск або пам'ять)
+	c, err := cache.New(cache.Options{
+		Backend:    cfg.CacheBackend,
+		Dir:        cfg.CacheDir,
+		MaxEntries: cfg.CacheMaxItems,
+		Redis: cache.RedisOptions{
+			URL:              cfg.RedisURL,
+			Mode:             cfg.RedisMode,
+			Addrs:            cfg.RedisAddrs,
+			MasterName:       cfg.RedisMaster,
+			SentinelPassword: cfg.RedisSentinelPassword,
+			TLSCAFile:        cfg.RedisTLSCAFile,
+			TLSInsecure:      cfg.RedisTLSInsecure,
+		},
+		RedisRequired: cfg.RedisRequired,
+	})
+	if err != nil {
+		// Недоступний обов'язковий Redis — помилка конфігурації середовища,
+		// тому завершуємо роботу з ненульовим кодом
+		logger.Error("Помилка при ініціалізації кешу: %v", err)
+		logger.Close()
+		log.Fatalf("Помилка при ініціалізації кешу: %v", err)
+	}
+	defer func() {
+		if err := c.Close(); err != nil {
+			logger.Error("Помилка при закритті кешу: %v", err)
+		}
+	}()
+
+	cf := fetcher.NewCachedFetcher(f, c, fetcher.CacheTTL{
+		Robots:      cfg.RobotsTTL,
+		Conditional: cfg.ConditionalTTL,
+		Snapshot:    cfg.SnapshotTTL,
+	})
+	chk := checker.New(cfg, cf)
 
 	// Контекст з таймаутом
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
 	defer cancel()
 
 	// Завантаження sitemap.xml
-	data, err := fetcher.FetchSitemap(ctx, cfg.SitemapURL)
+	data, err := cf.FetchSitemap(ctx, cfg.Rewrites.Apply(cfg.SitemapURL))
 	if err != nil {
 		logger.Error("Помилка при завантаженні sitemap: %v", err)
 		return
@@ -51,21 +100,33 @@ func main() {
 	// Обробка вмісту sitemap
 	switch content := sitemapContent.(type) {
 	case *parser.URLSet:
+		content.Source = cfg.SitemapURL
 		wg.Add(1)
-		checker.ProcessURLSet(ctx, content, &wg, sem, cfg)
+		chk.ProcessURLSet(ctx, content, &wg, sem)
 	case *parser.SitemapIndex:
 		wg.Add(1)
-		checker.ProcessSitemapIndex(ctx, content, 1, &wg, sem, cfg)
+		chk.ProcessSitemapIndex(ctx, content, 1, &wg, sem)
 	default:
 		logger.Error("Невідомий тип вмісту sitemap")
 	}
 
 	wg.Wait()
 
+	// Обхід сайту за внутрішніми посиланнями та їх перевірка; обхід має власний
+	// бюджет часу, щоб не залежати від того, скільки TIMEOUT пішло на sitemap
+	crawlCtx, cancelCrawl := context.WithTimeout(context.Background(), cfg.CrawlTimeout)
+	defer cancelCrawl()
+	chk.Crawl(crawlCtx)
+
 	// Зберігаємо результати у JSON-файл
-	if err := checker.SaveResultsToJSON("results.json"); err != nil {
+	if err := chk.SaveResultsToJSON("results.json"); err != nil {
 		logger.Error("Помилка при збереженні результатів: %v", err)
 	}
 
+	// Експорт графа посилань
+	if err := chk.SaveLinkGraph(cfg.GraphOutput, cfg.GraphFormats); err != nil {
+		logger.Error("Помилка при збереженні графа посилань: %v", err)
+	}
+
 	logger.Info("Перевірка завершена.")
 }
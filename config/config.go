@@ -4,19 +4,71 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
+
+	"sitemap-checker/linkgraph"
+	"sitemap-checker/normalize"
+	"sitemap-checker/rewrite"
 )
 
 type Config struct {
-	SitemapURL    string        // URL до sitemap.xml
-	Timeout       time.Duration // Таймаут для HTTP-запитів
-	MaxGoroutines int           // Максимальна кількість паралельних goroutines
-	MaxDepth      int           // Максимальна глибина рекурсії для sitemapindex
-	MaxRedirects  int           // Максимальна кількість редіректів
-	RedisURL      string        // URL для підключення до Redis
+	SitemapURL             string              // URL до sitemap.xml
+	Timeout                time.Duration       // Таймаут для HTTP-запитів
+	MaxGoroutines          int                 // Максимальна кількість паралельних goroutines
+	MaxDepth               int                 // Максимальна глибина рекурсії для sitemapindex
+	MaxRedirects           int                 // Максимальна кількість редіректів
+	RedisURL               string              // URL для підключення до Redis
+	RedisMode              string              // Режим Redis: standalone, sentinel або cluster
+	RedisAddrs             []string            // Адреси вузлів Sentinel або Cluster
+	RedisMaster            string              // Ім'я master для Sentinel
+	RedisSentinelPassword  string              // Пароль Sentinel
+	RedisTLSCAFile         string              // Файл CA для TLS-з'єднання з Redis
+	RedisTLSInsecure       bool                // Вимкнути перевірку сертифіката Redis
+	RedisRequired          bool                // Завершувати роботу, якщо Redis недоступний
+	ProxyURL               string              // URL проксі: http://, socks5:// або socks5h://
+	DNSOverrides           map[string]string   // Перевизначення DNS: "host" або "host:port" → IP
+	Rewrites               *rewrite.Rules      // Правила переписування URL перед завантаженням
+	FetchBackend           string              // Джерело сторінок: http, dir або archive
+	FetchSource            string              // Шлях до каталогу або архіву для dir/archive
+	CacheBackend           string              // Тип кешу: auto, memory, disk або redis
+	CacheDir               string              // Каталог для кешу на диску
+	CacheMaxItems          int                 // Максимальна кількість записів кешу в пам'яті
+	RobotsTTL              time.Duration       // Час життя robots.txt у кеші
+	ConditionalTTL         time.Duration       // Час життя метаданих умовних запитів
+	SnapshotTTL            time.Duration       // Час життя знімків sitemap
+	UserAgent              string              // User-Agent для запитів і перевірки robots.txt
+	IndexingBots           []string            // Боти, чиї мета-теги та X-Robots-Tag враховуються
+	TitleMinLength         int                 // Мінімальна довжина заголовка, символів
+	TitleMaxLength         int                 // Максимальна довжина заголовка, символів
+	TitleMaxPixels         float64             // Максимальна ширина заголовка у видачі, px
+	DescriptionMinLength   int                 // Мінімальна довжина мета-опису, символів
+	DescriptionMaxLength   int                 // Максимальна довжина мета-опису, символів
+	DescriptionMaxPixels   float64             // Максимальна ширина мета-опису у видачі, px
+	NearDuplicateThreshold float64             // Поріг схожості SimHash для майже однакових сторінок (0–1)
+	NearDuplicateMinWords  int                 // Мінімальна кількість слів для порівняння сторінок
+	ContentNormalization   *normalize.Pipeline // Нормалізація вмісту перед обчисленням хешу
+	CheckLinks             bool                // Обходити сайт за внутрішніми посиланнями
+	CrawlScope             string              // Область обходу: host, domain або префікс URL
+	CrawlMaxDepth          int                 // Максимальна глибина обходу за посиланнями
+	CrawlTimeout           time.Duration       // Окремий бюджет часу на обхід сайту
+	ClickDepthThreshold    int                 // Допустима глибина кліків від головної сторінки
+	GraphFormats           []string            // Формати експорту графа посилань: dot, graphml, json
+	GraphOutput            string              // Префікс імені файлів графа посилань
+	CheckResources         bool                // Перевіряти зображення, стилі, скрипти та шрифти сторінок
+	CheckCanonicalTargets  bool                // Завантажувати сторінки за канонічними посиланнями
+	CheckOGImage           bool                // Завантажувати зображення og:image
+	CheckSoft404           bool                // Шукати сторінки-заглушки «не знайдено» зі статусом 200
+	Soft404TitlePatterns   []*regexp.Regexp    // Шаблони заголовка сторінки «не знайдено»
+	Soft404BodyPatterns    []*regexp.Regexp    // Шаблони основного тексту сторінки «не знайдено»
+	Soft404MinWords        int                 // Сторінки з меншою кількістю слів вважаються порожніми
+	Soft404Similarity      float64             // Поріг схожості зі сторінкою помилки хоста (0–1)
+	CheckSecurityHeaders   bool                // Перевіряти заголовки безпеки відповідей
+	CacheHitRatioThreshold float64             // Мінімальна частка влучань у кеш CDN для хоста (0–1)
 }
 
 func Load() (*Config, error) {
@@ -72,12 +124,398 @@ func Load() (*Config, error) {
 		redisURL = "redis:6379" // Значення за замовчуванням для Docker
 	}
 
+	// Режим та параметри підключення до Redis
+	redisMode := os.Getenv("REDIS_MODE")
+	if redisMode == "" {
+		redisMode = "standalone" // Значення за замовчуванням
+	}
+	redisTLSInsecure, err := parseBool("REDIS_TLS_INSECURE", false)
+	if err != nil {
+		return nil, err
+	}
+	redisRequired, err := parseBool("REDIS_REQUIRED", false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Перевизначення DNS у форматі host=ip,host:port=ip
+	dnsOverrides, err := parseMap(os.Getenv("DNS_OVERRIDES"))
+	if err != nil {
+		return nil, fmt.Errorf("невірний формат DNS_OVERRIDES: %v", err)
+	}
+
+	// Правила переписування хостів та префіксів шляху
+	rewrites, err := rewrite.Parse(os.Getenv("REWRITE_HOSTS"), os.Getenv("REWRITE_PATHS"), os.Getenv("SITEMAP_URL"))
+	if err != nil {
+		return nil, fmt.Errorf("невірний формат правил переписування: %v", err)
+	}
+
+	// Джерело сторінок
+	fetchBackend := os.Getenv("FETCH_BACKEND")
+	if fetchBackend == "" {
+		fetchBackend = "http" // Значення за замовчуванням
+	}
+	fetchSource := os.Getenv("FETCH_SOURCE")
+	if fetchBackend != "http" && fetchSource == "" {
+		return nil, fmt.Errorf("для FETCH_BACKEND=%s потрібно вказати FETCH_SOURCE", fetchBackend)
+	}
+
+	// Кеш
+	cacheBackend := os.Getenv("CACHE_BACKEND")
+	if cacheBackend == "" {
+		cacheBackend = "auto" // Redis, якщо доступний, інакше диск
+	}
+
+	cacheMaxItemsStr := os.Getenv("CACHE_MAX_ENTRIES")
+	cacheMaxItems := 1000 // Значення за замовчуванням
+	if cacheMaxItemsStr != "" {
+		cacheMaxItems, err = strconv.Atoi(cacheMaxItemsStr)
+		if err != nil {
+			return nil, fmt.Errorf("невірний формат CACHE_MAX_ENTRIES: %v", err)
+		}
+	}
+
+	robotsTTL, err := parseDuration("ROBOTS_CACHE_TTL", 24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	conditionalTTL, err := parseDuration("CONDITIONAL_CACHE_TTL", 7*24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+	snapshotTTL, err := parseDuration("SNAPSHOT_CACHE_TTL", 7*24*time.Hour)
+	if err != nil {
+		return nil, err
+	}
+
+	// User-Agent
+	userAgent := os.Getenv("USER_AGENT")
+	if userAgent == "" {
+		userAgent = "sitemap-checker/1.0" // Значення за замовчуванням
+	}
+
+	// Боти для перевірки індексації
+	indexingBots := parseList(strings.ToLower(os.Getenv("INDEXING_BOTS")))
+	if len(indexingBots) == 0 {
+		indexingBots = []string{"googlebot", "bingbot"} // Значення за замовчуванням
+	}
+
+	// Ліміти довжини заголовка та мета-опису
+	titleMinLength, err := parseInt("TITLE_MIN_LENGTH", 30)
+	if err != nil {
+		return nil, err
+	}
+	titleMaxLength, err := parseInt("TITLE_MAX_LENGTH", 60)
+	if err != nil {
+		return nil, err
+	}
+	titleMaxPixels, err := parseFloat("TITLE_MAX_PIXELS", 580)
+	if err != nil {
+		return nil, err
+	}
+	descriptionMinLength, err := parseInt("DESCRIPTION_MIN_LENGTH", 70)
+	if err != nil {
+		return nil, err
+	}
+	descriptionMaxLength, err := parseInt("DESCRIPTION_MAX_LENGTH", 160)
+	if err != nil {
+		return nil, err
+	}
+	descriptionMaxPixels, err := parseFloat("DESCRIPTION_MAX_PIXELS", 920)
+	if err != nil {
+		return nil, err
+	}
+
+	// Пошук майже однакових сторінок
+	nearDuplicateThreshold, err := parseFloat("NEAR_DUPLICATE_THRESHOLD", 0.9)
+	if err != nil {
+		return nil, err
+	}
+	if nearDuplicateThreshold <= 0 || nearDuplicateThreshold > 1 {
+		return nil, fmt.Errorf("NEAR_DUPLICATE_THRESHOLD має бути в межах (0, 1]: %v", nearDuplicateThreshold)
+	}
+	nearDuplicateMinWords, err := parseInt("NEAR_DUPLICATE_MIN_WORDS", 50)
+	if err != nil {
+		return nil, err
+	}
+
+	// Нормалізація вмісту перед пошуком точних дублів
+	collapseWhitespace, err := parseBool("CONTENT_COLLAPSE_WHITESPACE", true)
+	if err != nil {
+		return nil, err
+	}
+	contentNormalization, err := normalize.Parse(os.Getenv("CONTENT_REGION"), os.Getenv("CONTENT_STRIP_PATTERNS"), collapseWhitespace)
+	if err != nil {
+		return nil, fmt.Errorf("невірні налаштування нормалізації вмісту: %v", err)
+	}
+
+	// Перевірка внутрішніх посилань; обхід завантажує кожну знайдену адресу,
+	// тому вмикається явно
+	checkLinks, err := parseBool("CHECK_LINKS", false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Область та глибина обходу
+	crawlScope := os.Getenv("CRAWL_SCOPE")
+	if crawlScope == "" {
+		crawlScope = "host" // Значення за замовчуванням
+	}
+	if crawlScope != "host" && crawlScope != "domain" && !strings.HasPrefix(crawlScope, "http://") && !strings.HasPrefix(crawlScope, "https://") {
+		return nil, fmt.Errorf("невірний формат CRAWL_SCOPE: %s", crawlScope)
+	}
+	crawlMaxDepth, err := parseInt("CRAWL_MAX_DEPTH", 5)
+	if err != nil {
+		return nil, err
+	}
+	crawlTimeout, err := parseDuration("CRAWL_TIMEOUT", 10*time.Minute)
+	if err != nil {
+		return nil, err
+	}
+
+	clickDepthThreshold, err := parseInt("CLICK_DEPTH_THRESHOLD", 3)
+	if err != nil {
+		return nil, err
+	}
+
+	// Експорт графа посилань
+	graphFormats, err := linkgraph.ParseFormats(os.Getenv("GRAPH_FORMATS"))
+	if err != nil {
+		return nil, fmt.Errorf("невірний формат GRAPH_FORMATS: %v", err)
+	}
+	graphOutput := os.Getenv("GRAPH_OUTPUT")
+	if graphOutput == "" {
+		graphOutput = "link-graph" // Значення за замовчуванням
+	}
+
+	// Перевірка ресурсів сторінок; кожен ресурс — окремий запит у межах TIMEOUT,
+	// тому перевірка вмикається явно
+	checkResources, err := parseBool("CHECK_RESOURCES", false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Перевірка сторінок за канонічними посиланнями; кожна ціль — окремий запит
+	// у межах TIMEOUT, тому перевірка вмикається явно
+	checkCanonicalTargets, err := parseBool("CHECK_CANONICAL_TARGETS", false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Перевірка зображень og:image; кожне зображення — окремий запит у межах
+	// TIMEOUT, тому перевірка вмикається явно
+	checkOGImage, err := parseBool("CHECK_OG_IMAGE", false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Пошук soft-404; запити до неіснуючих адрес хостів виконуються в межах
+	// TIMEOUT, тому пошук вмикається явно
+	checkSoft404, err := parseBool("CHECK_SOFT404", false)
+	if err != nil {
+		return nil, err
+	}
+	soft404TitlePatterns, err := parsePatterns("SOFT404_TITLE_PATTERNS", []string{
+		`not found`, `\b404\b`, `не знайдено`, `не існує`,
+	})
+	if err != nil {
+		return nil, err
+	}
+	soft404BodyPatterns, err := parsePatterns("SOFT404_BODY_PATTERNS", []string{
+		`page (you requested )?(was |could )?not (be )?found`, `no longer available`,
+		`сторінк\S* не знайдено`, `більше не доступн`, `товар не знайдено`,
+	})
+	if err != nil {
+		return nil, err
+	}
+	soft404MinWords, err := parseInt("SOFT404_MIN_WORDS", 50)
+	if err != nil {
+		return nil, err
+	}
+	soft404Similarity, err := parseFloat("SOFT404_SIMILARITY", 0.9)
+	if err != nil {
+		return nil, err
+	}
+	if soft404Similarity <= 0 || soft404Similarity > 1 {
+		return nil, fmt.Errorf("SOFT404_SIMILARITY має бути в межах (0, 1]: %v", soft404Similarity)
+	}
+
+	// Аудит заголовків безпеки
+	checkSecurityHeaders, err := parseBool("CHECK_SECURITY_HEADERS", true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Частка влучань у кеш CDN
+	cacheHitRatioThreshold, err := parseFloat("CACHE_HIT_RATIO_THRESHOLD", 0.5)
+	if err != nil {
+		return nil, err
+	}
+	if cacheHitRatioThreshold < 0 || cacheHitRatioThreshold > 1 {
+		return nil, fmt.Errorf("CACHE_HIT_RATIO_THRESHOLD має бути в межах [0, 1]: %v", cacheHitRatioThreshold)
+	}
+
 	return &Config{
-		SitemapURL:    os.Getenv("SITEMAP_URL"),
-		Timeout:       timeout,
-		MaxGoroutines: maxGoroutines,
-		MaxDepth:      maxDepth,
-		MaxRedirects:  maxRedirects,
-		RedisURL:      redisURL,
+		SitemapURL:             os.Getenv("SITEMAP_URL"),
+		Timeout:                timeout,
+		MaxGoroutines:          maxGoroutines,
+		MaxDepth:               maxDepth,
+		MaxRedirects:           maxRedirects,
+		RedisURL:               redisURL,
+		RedisMode:              redisMode,
+		RedisAddrs:             parseList(os.Getenv("REDIS_ADDRS")),
+		RedisMaster:            os.Getenv("REDIS_SENTINEL_MASTER"),
+		RedisSentinelPassword:  os.Getenv("REDIS_SENTINEL_PASSWORD"),
+		RedisTLSCAFile:         os.Getenv("REDIS_TLS_CA_FILE"),
+		RedisTLSInsecure:       redisTLSInsecure,
+		RedisRequired:          redisRequired,
+		ProxyURL:               os.Getenv("PROXY_URL"),
+		DNSOverrides:           dnsOverrides,
+		Rewrites:               rewrites,
+		FetchBackend:           fetchBackend,
+		FetchSource:            fetchSource,
+		CacheBackend:           cacheBackend,
+		CacheDir:               os.Getenv("CACHE_DIR"),
+		CacheMaxItems:          cacheMaxItems,
+		RobotsTTL:              robotsTTL,
+		ConditionalTTL:         conditionalTTL,
+		SnapshotTTL:            snapshotTTL,
+		UserAgent:              userAgent,
+		IndexingBots:           indexingBots,
+		TitleMinLength:         titleMinLength,
+		TitleMaxLength:         titleMaxLength,
+		TitleMaxPixels:         titleMaxPixels,
+		DescriptionMinLength:   descriptionMinLength,
+		DescriptionMaxLength:   descriptionMaxLength,
+		DescriptionMaxPixels:   descriptionMaxPixels,
+		NearDuplicateThreshold: nearDuplicateThreshold,
+		NearDuplicateMinWords:  nearDuplicateMinWords,
+		ContentNormalization:   contentNormalization,
+		CheckLinks:             checkLinks,
+		CrawlScope:             crawlScope,
+		CrawlMaxDepth:          crawlMaxDepth,
+		CrawlTimeout:           crawlTimeout,
+		ClickDepthThreshold:    clickDepthThreshold,
+		GraphFormats:           graphFormats,
+		GraphOutput:            graphOutput,
+		CheckResources:         checkResources,
+		CheckCanonicalTargets:  checkCanonicalTargets,
+		CheckOGImage:           checkOGImage,
+		CheckSoft404:           checkSoft404,
+		Soft404TitlePatterns:   soft404TitlePatterns,
+		Soft404BodyPatterns:    soft404BodyPatterns,
+		Soft404MinWords:        soft404MinWords,
+		Soft404Similarity:      soft404Similarity,
+		CheckSecurityHeaders:   checkSecurityHeaders,
+		CacheHitRatioThreshold: cacheHitRatioThreshold,
 	}, nil
 }
+
+// parseDuration читає тривалість зі змінної середовища або повертає значення за замовчуванням
+func parseDuration(name string, defaultValue time.Duration) (time.Duration, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("невірний формат %s: %v", name, err)
+	}
+	return d, nil
+}
+
+// parseInt читає ціле число зі змінної середовища або повертає значення за замовчуванням
+func parseInt(name string, defaultValue int) (int, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("невірний формат %s: %v", name, err)
+	}
+	return n, nil
+}
+
+// parseFloat читає дробове число зі змінної середовища або повертає значення за замовчуванням
+func parseFloat(name string, defaultValue float64) (float64, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("невірний формат %s: %v", name, err)
+	}
+	return f, nil
+}
+
+// parseMap розбирає рядок виду key=value,key=value у мапу
+func parseMap(s string) (map[string]string, error) {
+	result := make(map[string]string)
+	if strings.TrimSpace(s) == "" {
+		return result, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+		if !ok || key == "" || value == "" {
+			return nil, fmt.Errorf("невірна пара %q", pair)
+		}
+		result[key] = value
+	}
+
+	return result, nil
+}
+
+// parseBool читає логічне значення зі змінної середовища або повертає значення за замовчуванням
+func parseBool(name string, defaultValue bool) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue, nil
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("невірний формат %s: %v", name, err)
+	}
+	return b, nil
+}
+
+// parsePatterns читає регулярні вирази (по одному в рядку) зі змінної середовища
+// або повертає значення за замовчуванням; регістр не враховується
+func parsePatterns(name string, defaultValue []string) ([]*regexp.Regexp, error) {
+	patterns := defaultValue
+	if value := os.Getenv(name); strings.TrimSpace(value) != "" {
+		patterns = strings.Split(value, "\n")
+	}
+
+	var result []*regexp.Regexp
+	for _, pattern := range patterns {
+		if pattern = strings.TrimSpace(pattern); pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile("(?i)" + pattern)
+		if err != nil {
+			return nil, fmt.Errorf("невірний регулярний вираз %q у %s: %v", pattern, name, err)
+		}
+		result = append(result, re)
+	}
+	return result, nil
+}
+
+// parseList розбирає рядок виду a,b,c у список без порожніх елементів
+func parseList(s string) []string {
+	var result []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
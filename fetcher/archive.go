@@ -0,0 +1,221 @@
+package fetcher
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path"
+	"sitemap-checker/logger"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ArchiveFetcher завантажує ресурси з tar- або WARC-архіву
+type ArchiveFetcher struct {
+	files     map[string][]byte    // Файли tar-архіву за відносним шляхом
+	responses map[string]*Response // Записи WARC за нормалізованим URL
+}
+
+// NewArchiveFetcher читає архів і будує індекс його вмісту.
+// Тип архіву визначається за розширенням: .tar, .tar.gz, .tgz, .warc, .warc.gz
+func NewArchiveFetcher(name string) (*ArchiveFetcher, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("помилка при відкритті архіву: %v", err)
+	}
+	defer func(file *os.File) {
+		err := file.Close()
+		if err != nil {
+			logger.Error("помилка при закритті архіву: %v", err)
+		}
+	}(file)
+
+	var r io.Reader = file
+	lower := strings.ToLower(name)
+	if strings.HasSuffix(lower, ".gz") || strings.HasSuffix(lower, ".tgz") {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return nil, fmt.Errorf("помилка при розпакуванні архіву: %v", err)
+		}
+		r = gz
+	}
+
+	f := &ArchiveFetcher{}
+	switch {
+	case strings.HasSuffix(lower, ".warc"), strings.HasSuffix(lower, ".warc.gz"):
+		f.responses, err = readWARC(r)
+	case strings.HasSuffix(lower, ".tar"), strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		f.files, err = readTar(r)
+	default:
+		err = fmt.Errorf("невідомий формат архіву: %s", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return f, nil
+}
+
+// Fetch шукає ресурс в індексі архіву
+func (f *ArchiveFetcher) Fetch(ctx context.Context, req *Request) (*Response, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil, fmt.Errorf("помилка при парсингу URL: %v", err)
+	}
+
+	start := time.Now()
+
+	if f.responses != nil {
+		if resp, ok := f.responses[archiveKey(u)]; ok {
+			result := *resp
+			result.LoadTime = time.Since(start)
+			return &result, nil
+		}
+		return notFoundResponse(req.URL, time.Since(start)), nil
+	}
+
+	for _, candidate := range localCandidates(u) {
+		if data, ok := f.files[candidate]; ok {
+			return localResponse(req.URL, candidate, data, time.Since(start)), nil
+		}
+	}
+
+	return notFoundResponse(req.URL, time.Since(start)), nil
+}
+
+// readTar читає всі звичайні файли tar-архіву в пам'ять
+func readTar(r io.Reader) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	tr := tar.NewReader(r)
+
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("помилка при читанні tar-архіву: %v", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("помилка при читанні файлу %s з архіву: %v", header.Name, err)
+		}
+		files[strings.TrimPrefix(path.Clean("/"+header.Name), "/")] = data
+	}
+
+	return files, nil
+}
+
+// readWARC читає записи response з WARC-файлу
+func readWARC(r io.Reader) (map[string]*Response, error) {
+	responses := make(map[string]*Response)
+	br := bufio.NewReader(r)
+
+	for {
+		// Пропускаємо порожні рядки між записами
+		line, err := br.ReadString('\n')
+		if errors.Is(err, io.EOF) && strings.TrimSpace(line) == "" {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("помилка при читанні WARC: %v", err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, "WARC/") {
+			return nil, fmt.Errorf("невірний заголовок запису WARC: %q", line)
+		}
+
+		header, err := textproto.NewReader(br).ReadMIMEHeader()
+		if err != nil {
+			return nil, fmt.Errorf("помилка при читанні заголовків WARC: %v", err)
+		}
+		length, err := strconv.ParseInt(header.Get("Content-Length"), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("невірний Content-Length запису WARC: %v", err)
+		}
+		block := make([]byte, length)
+		if _, err := io.ReadFull(br, block); err != nil {
+			return nil, fmt.Errorf("помилка при читанні блоку WARC: %v", err)
+		}
+
+		if header.Get("WARC-Type") != "response" || !strings.HasPrefix(header.Get("Content-Type"), "application/http") {
+			continue
+		}
+
+		targetURI := strings.Trim(header.Get("WARC-Target-URI"), "<>")
+		u, err := url.Parse(targetURI)
+		if err != nil {
+			logger.Error("невірний WARC-Target-URI %s: %v", targetURI, err)
+			continue
+		}
+
+		resp, err := parseWARCResponse(targetURI, block)
+		if err != nil {
+			logger.Error("помилка при розборі відповіді WARC для %s: %v", targetURI, err)
+			continue
+		}
+		responses[archiveKey(u)] = resp
+	}
+
+	return responses, nil
+}
+
+// parseWARCResponse розбирає збережену HTTP-відповідь із блоку WARC
+func parseWARCResponse(targetURI string, block []byte) (*Response, error) {
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(block)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var body io.Reader = resp.Body
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		body = gz
+		resp.Header.Del("Content-Encoding")
+	}
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Response{
+		URL:        targetURI,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       data,
+		Redirects:  make([]string, 0),
+	}, nil
+}
+
+// archiveKey нормалізує URL для пошуку в індексі WARC
+func archiveKey(u *url.URL) string {
+	key := *u
+	key.Scheme = strings.ToLower(key.Scheme)
+	key.Host = strings.ToLower(key.Host)
+	key.Fragment = ""
+	if key.Path == "" {
+		key.Path = "/"
+	}
+	return key.String()
+}
@@ -0,0 +1,183 @@
+package fetcher
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeTar створює tar-архів (за розширенням .tar.gz — стиснутий) з вказаними файлами
+func writeTar(t *testing.T, name string, files map[string]string) {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	if err := tw.WriteHeader(&tar.Header{Name: "./site/", Typeflag: tar.TypeDir, Mode: 0o755}); err != nil {
+		t.Fatal(err)
+	}
+	for fileName, content := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: fileName, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := buf.Bytes()
+	if strings.HasSuffix(name, ".gz") {
+		var gz bytes.Buffer
+		w := gzip.NewWriter(&gz)
+		w.Write(data)
+		w.Close()
+		data = gz.Bytes()
+	}
+	if err := os.WriteFile(name, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestArchiveTar(t *testing.T) {
+	for _, ext := range []string{".tar", ".tar.gz"} {
+		t.Run(ext, func(t *testing.T) {
+			name := filepath.Join(t.TempDir(), "site"+ext)
+			writeTar(t, name, map[string]string{
+				"./example.com/index.html":   "<html>головна</html>",
+				"example.com/blog/post.html": "<html>запис</html>",
+				"/assets/app.css":            "body{}",
+				"../outside.txt":             "за межами",
+			})
+
+			f, err := NewArchiveFetcher(name)
+			if err != nil {
+				t.Fatalf("NewArchiveFetcher: %v", err)
+			}
+
+			tests := []struct {
+				url    string
+				status int
+				body   string
+			}{
+				{"https://example.com/", http.StatusOK, "<html>головна</html>"},
+				{"https://EXAMPLE.com/blog/post", http.StatusOK, "<html>запис</html>"},
+				{"https://example.com/assets/app.css", http.StatusOK, "body{}"},
+				{"https://example.com/outside.txt", http.StatusOK, "за межами"}, // Шлях у архіві очищено від ..
+				{"https://example.com/missing", http.StatusNotFound, ""},
+			}
+			for _, tt := range tests {
+				resp, err := f.Fetch(context.Background(), &Request{URL: tt.url})
+				if err != nil {
+					t.Fatalf("Fetch(%s): %v", tt.url, err)
+				}
+				if resp.StatusCode != tt.status || string(resp.Body) != tt.body {
+					t.Errorf("Fetch(%s) = %d %q, очікувалось %d %q", tt.url, resp.StatusCode, resp.Body, tt.status, tt.body)
+				}
+			}
+		})
+	}
+}
+
+// warcRecord формує запис WARC із заголовками та блоком
+func warcRecord(warcType, targetURI, contentType, block string) string {
+	return fmt.Sprintf("WARC/1.0\r\nWARC-Type: %s\r\nWARC-Target-URI: <%s>\r\nContent-Type: %s\r\nContent-Length: %d\r\n\r\n%s\r\n\r\n",
+		warcType, targetURI, contentType, len(block), block)
+}
+
+func TestReadWARC(t *testing.T) {
+	var gzBody bytes.Buffer
+	gz := gzip.NewWriter(&gzBody)
+	gz.Write([]byte("<html>стиснута</html>"))
+	gz.Close()
+
+	warc := strings.Join([]string{
+		warcRecord("warcinfo", "", "application/warc-fields", "software: test\r\n"),
+		warcRecord("request", "https://example.com/", "application/http; msgtype=request", "GET / HTTP/1.1\r\nHost: example.com\r\n\r\n"),
+		warcRecord("response", "https://Example.com", "application/http; msgtype=response",
+			fmt.Sprintf("HTTP/1.1 200 OK\r\nContent-Type: text/html\r\nContent-Length: %d\r\n\r\n%s", len("<html>головна</html>"), "<html>головна</html>")),
+		warcRecord("response", "https://example.com/gone#top", "application/http; msgtype=response",
+			"HTTP/1.1 410 Gone\r\nContent-Length: 0\r\n\r\n"),
+		warcRecord("response", "https://example.com/gz", "application/http; msgtype=response",
+			fmt.Sprintf("HTTP/1.1 200 OK\r\nContent-Type: text/html\r\nContent-Encoding: gzip\r\nContent-Length: %d\r\n\r\n%s", gzBody.Len(), gzBody.String())),
+		warcRecord("response", "https://example.com/broken", "application/http; msgtype=response", "не HTTP"),
+	}, "")
+
+	responses, err := readWARC(strings.NewReader(warc))
+	if err != nil {
+		t.Fatalf("readWARC: %v", err)
+	}
+
+	tests := []struct {
+		key    string
+		status int
+		body   string
+	}{
+		{"https://example.com/", http.StatusOK, "<html>головна</html>"},
+		{"https://example.com/gone", http.StatusGone, ""},
+		{"https://example.com/gz", http.StatusOK, "<html>стиснута</html>"},
+	}
+	for _, tt := range tests {
+		resp, ok := responses[tt.key]
+		if !ok {
+			t.Errorf("запис %s не знайдено", tt.key)
+			continue
+		}
+		if resp.StatusCode != tt.status || string(resp.Body) != tt.body {
+			t.Errorf("%s = %d %q, очікувалось %d %q", tt.key, resp.StatusCode, resp.Body, tt.status, tt.body)
+		}
+	}
+	if resp := responses["https://example.com/gz"]; resp != nil && resp.Header.Get("Content-Encoding") != "" {
+		t.Errorf("Content-Encoding не видалено після розпакування")
+	}
+	if len(responses) != len(tests) {
+		t.Errorf("записів %d, очікувалось %d (request, warcinfo та некоректні відповіді пропускаються)", len(responses), len(tests))
+	}
+
+	// Помилки формату
+	for name, data := range map[string]string{
+		"не WARC":         "HTTP/1.1 200 OK\r\n\r\n",
+		"без довжини":     "WARC/1.0\r\nWARC-Type: response\r\n\r\n",
+		"обрізаний запис": "WARC/1.0\r\nWARC-Type: response\r\nContent-Length: 100\r\n\r\nкоротко",
+	} {
+		if _, err := readWARC(strings.NewReader(data)); err == nil {
+			t.Errorf("%s: readWARC не повернув помилку", name)
+		}
+	}
+}
+
+func TestArchiveWARCFetch(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "site.warc")
+	warc := warcRecord("response", "https://example.com/page?x=1", "application/http; msgtype=response",
+		"HTTP/1.1 200 OK\r\nContent-Type: text/html\r\nContent-Length: 4\r\n\r\npage")
+	if err := os.WriteFile(name, []byte(warc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := NewArchiveFetcher(name)
+	if err != nil {
+		t.Fatalf("NewArchiveFetcher: %v", err)
+	}
+	resp, err := f.Fetch(context.Background(), &Request{URL: "https://EXAMPLE.com/page?x=1#section"})
+	if err != nil || resp.StatusCode != http.StatusOK || string(resp.Body) != "page" {
+		t.Errorf("Fetch = %+v, %v; очікувалось 200 \"page\"", resp, err)
+	}
+	if resp, _ := f.Fetch(context.Background(), &Request{URL: "https://example.com/page"}); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Fetch без query-рядка = %d, очікувалось 404", resp.StatusCode)
+	}
+
+	zip := filepath.Join(t.TempDir(), "site.zip")
+	if err := os.WriteFile(zip, []byte("PK"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewArchiveFetcher(zip); err == nil {
+		t.Errorf("NewArchiveFetcher(.zip) не повернув помилку")
+	}
+}
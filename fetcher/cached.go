@@ -0,0 +1,140 @@
+package fetcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sitemap-checker/cache"
+	"sitemap-checker/logger"
+)
+
+// CacheTTL визначає час життя записів кешу для різних типів даних
+type CacheTTL struct {
+	Robots      time.Duration // robots.txt
+	Conditional time.Duration // ETag/Last-Modified для умовних запитів
+	Snapshot    time.Duration // Знімки вмісту sitemap
+}
+
+// CachedFetcher доповнює Fetcher кешуванням robots.txt, умовними запитами
+// та знімками sitemap
+type CachedFetcher struct {
+	Fetcher
+
+	robots      cache.Cache
+	conditional cache.Cache
+	snapshots   cache.Cache
+	ttl         CacheTTL
+}
+
+// validators — метадані для умовного запиту
+type validators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+// NewCachedFetcher створює CachedFetcher з окремими просторами імен у кеші c
+func NewCachedFetcher(f Fetcher, c cache.Cache, ttl CacheTTL) *CachedFetcher {
+	return &CachedFetcher{
+		Fetcher:     f,
+		robots:      cache.Namespace(c, "sitemap-checker:robots"),
+		conditional: cache.Namespace(c, "sitemap-checker:conditional"),
+		snapshots:   cache.Namespace(c, "sitemap-checker:snapshot"),
+		ttl:         ttl,
+	}
+}
+
+// FetchSitemap завантажує sitemap.xml умовним запитом і повертає збережений
+// знімок, якщо вміст не змінився
+func (f *CachedFetcher) FetchSitemap(ctx context.Context, url string) ([]byte, error) {
+	req := &Request{URL: url, Header: make(http.Header)}
+
+	snapshot, hasSnapshot := f.get(ctx, f.snapshots, url)
+	if hasSnapshot {
+		if data, ok := f.get(ctx, f.conditional, url); ok {
+			var v validators
+			if err := json.Unmarshal(data, &v); err == nil {
+				if v.ETag != "" {
+					req.Header.Set("If-None-Match", v.ETag)
+				}
+				if v.LastModified != "" {
+					req.Header.Set("If-Modified-Since", v.LastModified)
+				}
+			}
+		}
+	}
+
+	resp, err := f.Fetch(ctx, req)
+	if err != nil {
+		return nil, fmt.Errorf("помилка при завантаженні sitemap: %v", err)
+	}
+
+	if resp.StatusCode == http.StatusNotModified && hasSnapshot {
+		logger.Info("sitemap не змінився, використовується знімок: %s", url)
+		return snapshot, nil
+	}
+
+	// Перевірка статус-коду
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("неправильний статус код: %d", resp.StatusCode)
+	}
+
+	// Зберігаємо знімок та метадані для наступних умовних запитів
+	f.set(ctx, f.snapshots, url, resp.Body, f.ttl.Snapshot)
+	v := validators{ETag: resp.Header.Get("ETag"), LastModified: resp.Header.Get("Last-Modified")}
+	if v.ETag != "" || v.LastModified != "" {
+		data, _ := json.Marshal(v)
+		f.set(ctx, f.conditional, url, data, f.ttl.Conditional)
+	}
+
+	return resp.Body, nil
+}
+
+// robotsEntry — запис robots.txt у кеші
+type robotsEntry struct {
+	StatusCode int    `json:"status_code"`
+	Body       []byte `json:"body"`
+}
+
+// FetchRobotsTxt завантажує robots.txt з кешу або через Fetcher, слідуючи щонайбільше
+// за п'ятьма редіректами (RFC 9309). Повертає статус-код і тіло відповіді;
+// відповіді 5xx не кешуються
+func (f *CachedFetcher) FetchRobotsTxt(ctx context.Context, robotsURL string) (int, []byte, error) {
+	if data, ok := f.get(ctx, f.robots, robotsURL); ok {
+		var entry robotsEntry
+		if err := json.Unmarshal(data, &entry); err == nil {
+			return entry.StatusCode, entry.Body, nil
+		}
+	}
+
+	// Якщо немає в кеші, завантажуємо
+	resp, err := f.Fetch(ctx, &Request{URL: robotsURL, MaxRedirects: 5})
+	if err != nil {
+		return 0, nil, fmt.Errorf("помилка при завантаженні robots.txt: %w", err)
+	}
+
+	if resp.StatusCode < http.StatusInternalServerError {
+		data, _ := json.Marshal(robotsEntry{StatusCode: resp.StatusCode, Body: resp.Body})
+		f.set(ctx, f.robots, robotsURL, data, f.ttl.Robots)
+	}
+	return resp.StatusCode, resp.Body, nil
+}
+
+// get читає значення з кешу; помилки кешу логуються і вважаються промахом
+func (f *CachedFetcher) get(ctx context.Context, c cache.Cache, key string) ([]byte, bool) {
+	value, ok, err := c.Get(ctx, key)
+	if err != nil {
+		logger.Error("помилка при читанні кешу: %v", err)
+		return nil, false
+	}
+	return value, ok
+}
+
+// set записує значення в кеш; помилки кешу лише логуються
+func (f *CachedFetcher) set(ctx context.Context, c cache.Cache, key string, value []byte, ttl time.Duration) {
+	if err := c.Set(ctx, key, value, ttl); err != nil {
+		logger.Error("помилка при збереженні в кеш: %v", err)
+	}
+}
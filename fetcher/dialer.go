@@ -0,0 +1,326 @@
+package fetcher
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dialer встановлює TCP-з'єднання з урахуванням проксі та перевизначень DNS
+type Dialer struct {
+	Proxy        *url.URL          // Проксі-сервер: http://, socks5:// або socks5h://
+	DNSOverrides map[string]string // Перевизначення адрес: "host" або "host:port" → IP
+	dialer       net.Dialer
+}
+
+// defaultDialTimeout — таймаут з'єднання, якщо його не вказано
+const defaultDialTimeout = 30 * time.Second
+
+// NewDialer створює Dialer з вказаним проксі, перевизначеннями DNS і таймаутом з'єднання
+func NewDialer(proxyURL string, dnsOverrides map[string]string, timeout time.Duration) (*Dialer, error) {
+	if timeout <= 0 {
+		timeout = defaultDialTimeout
+	}
+	d := &Dialer{
+		DNSOverrides: dnsOverrides,
+		dialer: net.Dialer{
+			Timeout:   timeout,
+			KeepAlive: 30 * time.Second,
+		},
+	}
+
+	if proxyURL != "" {
+		proxy, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("помилка при парсингу URL проксі: %v", err)
+		}
+		switch proxy.Scheme {
+		case "http", "socks5", "socks5h":
+		default:
+			return nil, fmt.Errorf("непідтримувана схема проксі: %s", proxy.Scheme)
+		}
+		if proxy.Host == "" {
+			return nil, fmt.Errorf("не вказано адресу проксі: %s", proxyURL)
+		}
+		d.Proxy = proxy
+	}
+
+	return d, nil
+}
+
+// DialContext встановлює з'єднання з addr напряму або через проксі
+func (d *Dialer) DialContext(ctx context.Context, network, addr string) (net.Conn, error) {
+	target, overridden := d.resolveOverride(addr)
+
+	// З'єднання з самим HTTP-проксі (запити http:// в абсолютній формі, див. HTTPProxy)
+	// встановлюється напряму
+	if d.Proxy == nil || (d.Proxy.Scheme == "http" && addr == proxyAddr(d.Proxy)) {
+		return d.dialer.DialContext(ctx, network, target)
+	}
+
+	conn, err := d.dialer.DialContext(ctx, "tcp", proxyAddr(d.Proxy))
+	if err != nil {
+		return nil, fmt.Errorf("помилка при підключенні до проксі: %v", err)
+	}
+
+	// Обмежуємо рукостискання з проксі дедлайном контексту
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer func() { _ = conn.SetDeadline(time.Time{}) }()
+	}
+
+	switch d.Proxy.Scheme {
+	case "http":
+		err = d.connectHTTP(conn, target)
+	case "socks5":
+		// socks5 — адреса резолвиться локально, socks5h — на стороні проксі
+		if !overridden {
+			target, err = d.resolveLocal(ctx, target)
+			if err != nil {
+				break
+			}
+		}
+		err = d.connectSOCKS5(conn, target)
+	case "socks5h":
+		err = d.connectSOCKS5(conn, target)
+	}
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// HTTPProxy повертає HTTP-проксі для запиту (для http.Transport.Proxy): запити
+// http:// надсилаються проксі в абсолютній формі, бо багато проксі дозволяють
+// CONNECT лише до порту 443. Запити https:// та до хостів з DNSOverrides
+// тунелюються через CONNECT у DialContext
+func (d *Dialer) HTTPProxy(req *http.Request) (*url.URL, error) {
+	if d.Proxy == nil || d.Proxy.Scheme != "http" || req.URL.Scheme != "http" {
+		return nil, nil
+	}
+	port := req.URL.Port()
+	if port == "" {
+		port = "80"
+	}
+	if _, overridden := d.resolveOverride(net.JoinHostPort(req.URL.Hostname(), port)); overridden {
+		return nil, nil
+	}
+	return d.Proxy, nil
+}
+
+// resolveOverride підміняє хост на IP з DNSOverrides, якщо для нього є правило
+func (d *Dialer) resolveOverride(addr string) (string, bool) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr, false
+	}
+
+	// Правило для конкретного порту має пріоритет над правилом для хоста
+	if ip, ok := d.DNSOverrides[strings.ToLower(net.JoinHostPort(host, port))]; ok {
+		return net.JoinHostPort(ip, port), true
+	}
+	if ip, ok := d.DNSOverrides[strings.ToLower(host)]; ok {
+		return net.JoinHostPort(ip, port), true
+	}
+
+	return addr, false
+}
+
+// resolveLocal резолвить хост локально для проксі socks5
+func (d *Dialer) resolveLocal(ctx context.Context, addr string) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("помилка при розборі адреси %s: %v", addr, err)
+	}
+	if net.ParseIP(host) != nil {
+		return addr, nil
+	}
+
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return "", fmt.Errorf("помилка при резолві %s: %v", host, err)
+	}
+	if len(ips) == 0 {
+		return "", fmt.Errorf("не знайдено IP-адрес для %s", host)
+	}
+
+	return net.JoinHostPort(ips[0].IP.String(), port), nil
+}
+
+// connectHTTP відкриває тунель до target через HTTP CONNECT
+func (d *Dialer) connectHTTP(conn net.Conn, target string) error {
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: target},
+		Host:   target,
+		Header: make(http.Header),
+	}
+	if user := d.Proxy.User; user != nil {
+		password, _ := user.Password()
+		credentials := base64.StdEncoding.EncodeToString([]byte(user.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+credentials)
+	}
+
+	if err := req.Write(conn); err != nil {
+		return fmt.Errorf("помилка при надсиланні CONNECT: %v", err)
+	}
+
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		return fmt.Errorf("помилка при читанні відповіді проксі: %v", err)
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("проксі відхилив CONNECT до %s: %s", target, resp.Status)
+	}
+	if br.Buffered() > 0 {
+		return errors.New("проксі надіслав дані до завершення CONNECT")
+	}
+
+	return nil
+}
+
+// connectSOCKS5 відкриває з'єднання з target через SOCKS5 (RFC 1928, RFC 1929)
+func (d *Dialer) connectSOCKS5(conn net.Conn, target string) error {
+	host, portStr, err := net.SplitHostPort(target)
+	if err != nil {
+		return fmt.Errorf("помилка при розборі адреси %s: %v", target, err)
+	}
+	port, err := strconv.Atoi(portStr)
+	if err != nil {
+		return fmt.Errorf("невірний порт %s: %v", portStr, err)
+	}
+
+	// Вітання: пропонуємо методи автентифікації
+	methods := []byte{0x00} // Без автентифікації
+	if d.Proxy.User != nil {
+		methods = append(methods, 0x02) // Логін/пароль
+	}
+	greeting := append([]byte{0x05, byte(len(methods))}, methods...)
+	if _, err := conn.Write(greeting); err != nil {
+		return fmt.Errorf("помилка при вітанні SOCKS5: %v", err)
+	}
+
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return fmt.Errorf("помилка при читанні відповіді SOCKS5: %v", err)
+	}
+	if reply[0] != 0x05 {
+		return fmt.Errorf("невірна версія SOCKS: %d", reply[0])
+	}
+
+	switch reply[1] {
+	case 0x00:
+	case 0x02:
+		if err := d.authSOCKS5(conn); err != nil {
+			return err
+		}
+	default:
+		return errors.New("проксі SOCKS5 не підтримує запропоновані методи автентифікації")
+	}
+
+	// Запит CONNECT
+	req := []byte{0x05, 0x01, 0x00}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			req = append(req, 0x01)
+			req = append(req, ip4...)
+		} else {
+			req = append(req, 0x04)
+			req = append(req, ip.To16()...)
+		}
+	} else {
+		if len(host) > 255 {
+			return fmt.Errorf("занадто довге ім'я хоста: %s", host)
+		}
+		req = append(req, 0x03, byte(len(host)))
+		req = append(req, host...)
+	}
+	req = binary.BigEndian.AppendUint16(req, uint16(port))
+
+	if _, err := conn.Write(req); err != nil {
+		return fmt.Errorf("помилка при надсиланні запиту SOCKS5: %v", err)
+	}
+
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return fmt.Errorf("помилка при читанні відповіді SOCKS5: %v", err)
+	}
+	if header[1] != 0x00 {
+		return fmt.Errorf("проксі SOCKS5 відхилив з'єднання до %s: код %d", target, header[1])
+	}
+
+	// Пропускаємо адресу, до якої прив'язався проксі
+	var skip int
+	switch header[3] {
+	case 0x01:
+		skip = net.IPv4len
+	case 0x04:
+		skip = net.IPv6len
+	case 0x03:
+		length := make([]byte, 1)
+		if _, err := io.ReadFull(conn, length); err != nil {
+			return fmt.Errorf("помилка при читанні відповіді SOCKS5: %v", err)
+		}
+		skip = int(length[0])
+	default:
+		return fmt.Errorf("невідомий тип адреси SOCKS5: %d", header[3])
+	}
+	if _, err := io.ReadFull(conn, make([]byte, skip+2)); err != nil {
+		return fmt.Errorf("помилка при читанні відповіді SOCKS5: %v", err)
+	}
+
+	return nil
+}
+
+// authSOCKS5 виконує автентифікацію за логіном і паролем (RFC 1929)
+func (d *Dialer) authSOCKS5(conn net.Conn) error {
+	username := d.Proxy.User.Username()
+	password, _ := d.Proxy.User.Password()
+	if len(username) > 255 || len(password) > 255 {
+		return errors.New("занадто довгі облікові дані SOCKS5")
+	}
+
+	req := []byte{0x01, byte(len(username))}
+	req = append(req, username...)
+	req = append(req, byte(len(password)))
+	req = append(req, password...)
+	if _, err := conn.Write(req); err != nil {
+		return fmt.Errorf("помилка при автентифікації SOCKS5: %v", err)
+	}
+
+	reply := make([]byte, 2)
+	if _, err := io.ReadFull(conn, reply); err != nil {
+		return fmt.Errorf("помилка при читанні відповіді автентифікації SOCKS5: %v", err)
+	}
+	if reply[1] != 0x00 {
+		return errors.New("невірні облікові дані SOCKS5")
+	}
+
+	return nil
+}
+
+// proxyAddr повертає адресу проксі з портом за замовчуванням
+func proxyAddr(proxy *url.URL) string {
+	if proxy.Port() != "" {
+		return proxy.Host
+	}
+	if proxy.Scheme == "http" {
+		return net.JoinHostPort(proxy.Hostname(), "80")
+	}
+	return net.JoinHostPort(proxy.Hostname(), "1080")
+}
@@ -0,0 +1,166 @@
+package fetcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// FileFetcher завантажує ресурси за URL виду file:///path/to/file
+type FileFetcher struct{}
+
+// Fetch читає локальний файл
+func (f *FileFetcher) Fetch(ctx context.Context, req *Request) (*Response, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil, fmt.Errorf("помилка при парсингу URL: %v", err)
+	}
+	if u.Scheme != "file" {
+		return nil, fmt.Errorf("непідтримувана схема: %s", u.Scheme)
+	}
+
+	start := time.Now()
+	name := filepath.FromSlash(u.Path)
+
+	// Для каталогу віддаємо index.html
+	if info, err := os.Stat(name); err == nil && info.IsDir() {
+		name = filepath.Join(name, "index.html")
+	}
+
+	data, err := os.ReadFile(name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return notFoundResponse(req.URL, time.Since(start)), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("помилка при читанні файлу: %v", err)
+	}
+
+	return localResponse(req.URL, name, data, time.Since(start)), nil
+}
+
+// DirFetcher завантажує ресурси з локального дзеркала сайту (наприклад, wget -m
+// або результат збірки статичного сайту)
+type DirFetcher struct {
+	Root string // Кореневий каталог дзеркала
+}
+
+// NewDirFetcher створює DirFetcher для вказаного каталогу
+func NewDirFetcher(root string) (*DirFetcher, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, fmt.Errorf("помилка при відкритті каталогу %s: %v", root, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s не є каталогом", root)
+	}
+	return &DirFetcher{Root: root}, nil
+}
+
+// Fetch шукає локальну копію URL у каталозі дзеркала
+func (f *DirFetcher) Fetch(ctx context.Context, req *Request) (*Response, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return nil, fmt.Errorf("помилка при парсингу URL: %v", err)
+	}
+
+	start := time.Now()
+	for _, candidate := range localCandidates(u) {
+		name := filepath.Join(f.Root, filepath.FromSlash(candidate))
+		// Шлях має залишатися в межах дзеркала навіть після очищення
+		if rel, err := filepath.Rel(f.Root, name); err != nil || !filepath.IsLocal(rel) {
+			continue
+		}
+		info, err := os.Stat(name)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("помилка при читанні файлу: %v", err)
+		}
+		return localResponse(req.URL, candidate, data, time.Since(start)), nil
+	}
+
+	return notFoundResponse(req.URL, time.Since(start)), nil
+}
+
+// localCandidates повертає можливі відносні шляхи локальної копії URL:
+// спочатку з хостом у першому сегменті (як у wget -m), потім без нього.
+// Шляхи, що виходять за межі кореня (.. у query-рядку чи хості), відкидаються
+func localCandidates(u *url.URL) []string {
+	name := strings.TrimPrefix(path.Clean("/"+u.Path), "/")
+
+	var names []string
+	if name == "" || strings.HasSuffix(u.Path, "/") {
+		names = append(names, path.Join(name, "index.html"))
+	} else {
+		if u.RawQuery != "" {
+			names = append(names, name+"?"+u.RawQuery)
+		}
+		names = append(names, name, path.Join(name, "index.html"))
+		if path.Ext(name) == "" {
+			names = append(names, name+".html")
+		}
+	}
+
+	var candidates []string
+	if u.Host != "" {
+		for _, n := range names {
+			candidates = append(candidates, path.Join(strings.ToLower(u.Host), n))
+		}
+	}
+	candidates = append(candidates, names...)
+
+	local := candidates[:0]
+	for _, candidate := range candidates {
+		if filepath.IsLocal(filepath.FromSlash(candidate)) {
+			local = append(local, candidate)
+		}
+	}
+	return local
+}
+
+// localResponse формує відповідь для локально знайденого ресурсу
+func localResponse(rawURL, name string, data []byte, loadTime time.Duration) *Response {
+	// Для імен з query-рядком (page?x=1) розширення визначаємо без нього
+	name, _, _ = strings.Cut(name, "?")
+
+	contentType := mime.TypeByExtension(path.Ext(filepath.ToSlash(name)))
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+
+	header := make(http.Header)
+	header.Set("Content-Type", contentType)
+
+	return &Response{
+		URL:        rawURL,
+		StatusCode: http.StatusOK,
+		Header:     header,
+		Body:       data,
+		Redirects:  make([]string, 0),
+		LoadTime:   loadTime,
+	}
+}
+
+// notFoundResponse формує відповідь 404 для відсутнього локального ресурсу
+func notFoundResponse(rawURL string, loadTime time.Duration) *Response {
+	return &Response{
+		URL:        rawURL,
+		StatusCode: http.StatusNotFound,
+		Header:     make(http.Header),
+		Body:       []byte{},
+		Redirects:  make([]string, 0),
+		LoadTime:   loadTime,
+	}
+}
@@ -0,0 +1,150 @@
+package fetcher
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"sitemap-checker/logger"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "fetcher-test-")
+	if err != nil {
+		panic(err)
+	}
+	logger.Init(filepath.Join(dir, "errors.log"))
+	code := m.Run()
+	logger.Close()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func TestLocalCandidates(t *testing.T) {
+	tests := []struct {
+		url  string
+		want []string
+	}{
+		{"https://Example.com/", []string{"example.com/index.html", "index.html"}},
+		{"https://example.com/blog/", []string{"example.com/blog/index.html", "blog/index.html"}},
+		{
+			"https://example.com/about",
+			[]string{"example.com/about", "example.com/about/index.html", "example.com/about.html", "about", "about/index.html", "about.html"},
+		},
+		{"https://example.com/a.pdf", []string{"example.com/a.pdf", "example.com/a.pdf/index.html", "a.pdf", "a.pdf/index.html"}},
+		{
+			"https://example.com/page?x=1",
+			[]string{"example.com/page?x=1", "example.com/page", "example.com/page/index.html", "example.com/page.html", "page?x=1", "page", "page/index.html", "page.html"},
+		},
+		{"/a/../../b.html", []string{"b.html", "b.html/index.html"}},
+		// Вихід за межі кореня через query-рядок або хост відкидається
+		{
+			"https://example.com/page?x/../../../etc/passwd",
+			[]string{"example.com/page", "example.com/page/index.html", "example.com/page.html", "page", "page/index.html", "page.html"},
+		},
+		{"http://../etc/passwd", []string{"etc/passwd", "etc/passwd/index.html", "etc/passwd.html"}},
+	}
+
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatalf("url.Parse(%q): %v", tt.url, err)
+		}
+		if got := localCandidates(u); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("localCandidates(%q) = %q, очікувалось %q", tt.url, got, tt.want)
+		}
+	}
+}
+
+// writeFiles створює файли з вмістом у каталозі dir
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		full := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(full), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDirFetcher(t *testing.T) {
+	dir := t.TempDir()
+	root := filepath.Join(dir, "site")
+	writeFiles(t, dir, map[string]string{
+		"secret.txt":                   "секрет",
+		"site/example.com/index.html":  "<html>головна</html>",
+		"site/example.com/about.html":  "<html>про нас</html>",
+		"site/docs/guide.pdf":          "%PDF-1.4",
+		"site/example.com/page?x=1":    "<html>сторінка з параметром</html>",
+		"site/example.com/page/a.html": "<html>вкладена</html>",
+	})
+
+	f, err := NewDirFetcher(root)
+	if err != nil {
+		t.Fatalf("NewDirFetcher: %v", err)
+	}
+
+	tests := []struct {
+		url         string
+		status      int
+		body        string
+		contentType string
+	}{
+		{"https://example.com/", http.StatusOK, "<html>головна</html>", "text/html; charset=utf-8"},
+		{"https://example.com/about", http.StatusOK, "<html>про нас</html>", "text/html; charset=utf-8"},
+		{"https://example.com/docs/guide.pdf", http.StatusOK, "%PDF-1.4", "application/pdf"},
+		{"https://example.com/page?x=1", http.StatusOK, "<html>сторінка з параметром</html>", "text/html; charset=utf-8"},
+		{"https://example.com/missing", http.StatusNotFound, "", ""},
+		{"https://example.com/x?/../../secret.txt", http.StatusNotFound, "", ""},
+		{"https://example.com/page?x/../../../secret.txt", http.StatusNotFound, "", ""},
+	}
+	for _, tt := range tests {
+		resp, err := f.Fetch(context.Background(), &Request{URL: tt.url})
+		if err != nil {
+			t.Fatalf("Fetch(%s): %v", tt.url, err)
+		}
+		if resp.StatusCode != tt.status || string(resp.Body) != tt.body {
+			t.Errorf("Fetch(%s) = %d %q, очікувалось %d %q", tt.url, resp.StatusCode, resp.Body, tt.status, tt.body)
+		}
+		if tt.contentType != "" && resp.Header.Get("Content-Type") != tt.contentType {
+			t.Errorf("Fetch(%s): Content-Type = %q, очікувалось %q", tt.url, resp.Header.Get("Content-Type"), tt.contentType)
+		}
+	}
+}
+
+func TestFileFetcher(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"site/index.html": "<html>індекс</html>", "sitemap.xml": "<urlset/>"})
+	f := &FileFetcher{}
+
+	tests := []struct {
+		path   string
+		status int
+		body   string
+	}{
+		{"sitemap.xml", http.StatusOK, "<urlset/>"},
+		{"site", http.StatusOK, "<html>індекс</html>"},
+		{"missing.xml", http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		rawURL := (&url.URL{Scheme: "file", Path: filepath.ToSlash(filepath.Join(dir, tt.path))}).String()
+		resp, err := f.Fetch(context.Background(), &Request{URL: rawURL})
+		if err != nil {
+			t.Fatalf("Fetch(%s): %v", rawURL, err)
+		}
+		if resp.StatusCode != tt.status || string(resp.Body) != tt.body {
+			t.Errorf("Fetch(%s) = %d %q, очікувалось %d %q", tt.path, resp.StatusCode, resp.Body, tt.status, tt.body)
+		}
+	}
+
+	if _, err := f.Fetch(context.Background(), &Request{URL: "https://example.com/"}); err == nil {
+		t.Errorf("Fetch з https:// не повернув помилку")
+	}
+}
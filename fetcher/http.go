@@ -0,0 +1,91 @@
+package fetcher
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sitemap-checker/logger"
+	"time"
+)
+
+// HTTPFetcher завантажує ресурси через HTTP(S)
+type HTTPFetcher struct {
+	transport    http.RoundTripper
+	maxRedirects int
+	userAgent    string
+}
+
+// NewHTTPFetcher створює HTTPFetcher з проксі, перевизначеннями DNS і таймаутом з'єднання
+func NewHTTPFetcher(proxyURL string, dnsOverrides map[string]string, timeout time.Duration, maxRedirects int, userAgent string) (*HTTPFetcher, error) {
+	dialer, err := NewDialer(proxyURL, dnsOverrides, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = dialer.HTTPProxy // Лише http:// через HTTP-проксі; решта обробляється в Dialer
+	transport.DialContext = dialer.DialContext
+
+	return &HTTPFetcher{transport: transport, maxRedirects: maxRedirects, userAgent: userAgent}, nil
+}
+
+// Fetch завантажує ресурс з підтримкою редіректів та вимірюванням часу
+func (f *HTTPFetcher) Fetch(ctx context.Context, req *Request) (*Response, error) {
+	maxRedirects := f.maxRedirects
+	if req.MaxRedirects > 0 {
+		maxRedirects = req.MaxRedirects
+	}
+
+	redirects := make([]string, 0)
+	client := &http.Client{
+		Transport: f.transport,
+		CheckRedirect: func(r *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("%w: %d", ErrTooManyRedirects, maxRedirects)
+			}
+			redirects = append(redirects, r.URL.String())
+			return nil
+		},
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, req.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("помилка при створенні запиту: %v", err)
+	}
+	if f.userAgent != "" {
+		httpReq.Header.Set("User-Agent", f.userAgent)
+	}
+	for key, values := range req.Header {
+		httpReq.Header[key] = values
+	}
+
+	start := time.Now()
+
+	// Виконання запиту
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("помилка при завантаженні: %w", err)
+	}
+	defer func(Body io.ReadCloser) {
+		err := Body.Close()
+		if err != nil {
+			logger.Error("помилка при закритті тіла відповіді: %v", err)
+		}
+	}(resp.Body)
+
+	// Читання тіла відповіді
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("помилка при читанні тіла відповіді: %v", err)
+	}
+
+	return &Response{
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+		Redirects:  redirects,
+		LoadTime:   time.Since(start),
+	}, nil
+}
@@ -0,0 +1,308 @@
+package htmldoc
+
+import (
+	"strings"
+)
+
+// voidElements — елементи без закриваючого тегу
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true,
+	"hr": true, "img": true, "input": true, "link": true, "meta": true,
+	"param": true, "source": true, "track": true, "wbr": true,
+}
+
+// headElements — елементи, які можуть належати до <head> без явного тегу head
+var headElements = map[string]bool{
+	"base": true, "link": true, "meta": true, "noscript": true,
+	"script": true, "style": true, "template": true, "title": true,
+}
+
+// Element — елемент HTML-документа
+type Element struct {
+	Tag    string
+	Attrs  []Attribute
+	Text   string   // Вміст script, style, title та textarea
+	InHead bool     // Елемент належить до <head>
+	Parent *Element // Найближчий відкритий батьківський елемент
+	Start  int      // Зміщення початку відкриваючого тегу
+	End    int      // Зміщення кінця закриваючого тегу (або відкриваючого для void-елементів)
+	Closed bool     // Елемент закрито власним закриваючим тегом
+}
+
+// Attr повертає значення атрибута; порожній рядок, якщо атрибута немає
+func (e *Element) Attr(key string) string {
+	v, _ := e.LookupAttr(key)
+	return v
+}
+
+// LookupAttr повертає значення атрибута та ознаку його наявності
+func (e *Element) LookupAttr(key string) (string, bool) {
+	for _, a := range e.Attrs {
+		if a.Key == key {
+			return a.Val, true
+		}
+	}
+	return "", false
+}
+
+// HasToken перевіряє, чи містить атрибут зі списком токенів (rel, class) вказаний токен
+func (e *Element) HasToken(key, token string) bool {
+	for _, t := range strings.Fields(e.Attr(key)) {
+		if strings.EqualFold(t, token) {
+			return true
+		}
+	}
+	return false
+}
+
+// Document — розібраний HTML-документ
+type Document struct {
+	Source   string     // Вихідний HTML
+	Title    string     // Вміст першого <title>
+	HasTitle bool       // Чи містить документ <title> (можливо, порожній)
+	Lang     string     // Атрибут lang елемента <html>
+	Elements []*Element // Усі елементи в порядку появи
+}
+
+// Parse розбирає HTML-документ за один прохід
+func Parse(data []byte) *Document {
+	doc := &Document{Source: string(data)}
+	t := NewTokenizer(doc.Source)
+
+	var stack []*Element
+	seenHead, inHead, seenBody := false, false, false
+
+	for {
+		tok, ok := t.Next()
+		if !ok {
+			break
+		}
+
+		switch tok.Type {
+		case StartTagToken, SelfClosingTagToken:
+			switch tok.Data {
+			case "head":
+				seenHead, inHead = true, true
+			case "body":
+				seenBody, inHead = true, false
+			}
+
+			// Посилання не вкладаються: нове <a> закриває відкрите (як у специфікації HTML)
+			if tok.Data == "a" {
+				for i := len(stack) - 1; i >= 0; i-- {
+					if stack[i].Tag == "a" {
+						for _, el := range stack[i:] {
+							el.End = tok.Start
+						}
+						stack = stack[:i]
+						break
+					}
+				}
+			}
+
+			var parent *Element
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1]
+			}
+
+			el := &Element{
+				Tag:    tok.Data,
+				Attrs:  tok.Attr,
+				InHead: inHead || (!seenHead && !seenBody && headElements[tok.Data]),
+				Parent: parent,
+				Start:  tok.Start,
+				End:    tok.End,
+			}
+			doc.Elements = append(doc.Elements, el)
+
+			if tok.Data == "html" && doc.Lang == "" {
+				doc.Lang = el.Attr("lang")
+			}
+
+			if tok.Type == SelfClosingTagToken || voidElements[tok.Data] {
+				continue
+			}
+
+			// Вміст сирого тексту читаємо одразу разом із закриваючим тегом
+			if rawTextElements[tok.Data] {
+				if text, ok := t.Next(); ok && text.Type == TextToken {
+					el.Text = text.Data
+					el.End = text.End
+				}
+				if end, ok := t.Next(); ok {
+					el.End = end.End
+				}
+				if tok.Data == "title" && !doc.HasTitle && !insideSVG(el) {
+					doc.Title = strings.TrimSpace(el.Text)
+					doc.HasTitle = true
+				}
+				continue
+			}
+
+			stack = append(stack, el)
+		case EndTagToken:
+			if tok.Data == "head" {
+				inHead = false
+			}
+
+			// Закриваємо найближчий відповідний елемент та всі незакриті всередині нього
+			for i := len(stack) - 1; i >= 0; i-- {
+				if stack[i].Tag != tok.Data {
+					continue
+				}
+				for _, el := range stack[i:] {
+					el.End = tok.End
+				}
+				stack[i].Closed = true
+				stack = stack[:i]
+				break
+			}
+		}
+	}
+
+	// Незакриті елементи тягнуться до кінця документа
+	for _, el := range stack {
+		el.End = len(doc.Source)
+	}
+
+	return doc
+}
+
+// Find повертає всі елементи з вказаним тегом
+func (d *Document) Find(tag string) []*Element {
+	var result []*Element
+	for _, el := range d.Elements {
+		if el.Tag == tag {
+			result = append(result, el)
+		}
+	}
+	return result
+}
+
+// Meta повертає значення content усіх <meta> з вказаним name (без урахування регістру)
+func (d *Document) Meta(name string) []string {
+	var result []string
+	for _, el := range d.Elements {
+		if el.Tag == "meta" && strings.EqualFold(strings.TrimSpace(el.Attr("name")), name) {
+			result = append(result, el.Attr("content"))
+		}
+	}
+	return result
+}
+
+// MetaPrefixed повертає пари ключ/content усіх <meta>, у яких property або name
+// починається з prefix (без урахування регістру); ключі — у нижньому регістрі
+func (d *Document) MetaPrefixed(prefix string) []Attribute {
+	var result []Attribute
+	for _, el := range d.Elements {
+		if el.Tag != "meta" {
+			continue
+		}
+		key, ok := el.LookupAttr("property")
+		if !ok {
+			key = el.Attr("name")
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		if strings.HasPrefix(key, prefix) {
+			result = append(result, Attribute{Key: key, Val: strings.TrimSpace(el.Attr("content"))})
+		}
+	}
+	return result
+}
+
+// HeadLinks повертає елементи <link> у <head> з вказаним значенням rel
+func (d *Document) HeadLinks(rel string) []*Element {
+	var result []*Element
+	for _, el := range d.Elements {
+		if el.Tag == "link" && el.InHead && el.HasToken("rel", rel) {
+			result = append(result, el)
+		}
+	}
+	return result
+}
+
+// InnerHTML повертає вихідний HTML між відкриваючим і закриваючим тегами елемента
+func (d *Document) InnerHTML(el *Element) string {
+	t := NewTokenizer(d.Source[el.Start:el.End])
+	start, ok := t.Next()
+	if !ok {
+		return ""
+	}
+	inner := d.Source[el.Start+start.End : el.End]
+	if i := strings.LastIndex(inner, "</"); i >= 0 && strings.HasPrefix(strings.ToLower(inner[i+2:]), el.Tag) {
+		inner = inner[:i]
+	}
+	return inner
+}
+
+// InnerText повертає видимий текст елемента без script та style, зі стиснутими пробілами
+func (d *Document) InnerText(el *Element) string {
+	return visibleText(d.Source[el.Start:el.End])
+}
+
+// Text повертає видимий текст усього документа
+func (d *Document) Text() string {
+	if body := d.Find("body"); len(body) > 0 {
+		return d.InnerText(body[0])
+	}
+	return visibleText(d.Source)
+}
+
+// MainText повертає видимий текст основного вмісту: першого <main>, елемента
+// з role="main" або <article>; якщо їх немає — текст усього документа
+func (d *Document) MainText() string {
+	for _, match := range []func(*Element) bool{
+		func(el *Element) bool { return el.Tag == "main" },
+		func(el *Element) bool { return el.HasToken("role", "main") },
+		func(el *Element) bool { return el.Tag == "article" },
+	} {
+		for _, el := range d.Elements {
+			if match(el) && !el.InHead {
+				return d.InnerText(el)
+			}
+		}
+	}
+	return d.Text()
+}
+
+// visibleText збирає текстові токени фрагмента, пропускаючи script, style та title
+func visibleText(src string) string {
+	t := NewTokenizer(src)
+	var b strings.Builder
+	skip := ""
+
+	for {
+		tok, ok := t.Next()
+		if !ok {
+			break
+		}
+		switch tok.Type {
+		case StartTagToken:
+			switch tok.Data {
+			case "script", "style", "title", "textarea", "template":
+				skip = tok.Data
+			}
+		case EndTagToken:
+			if tok.Data == skip {
+				skip = ""
+			}
+		case TextToken:
+			if skip == "" {
+				b.WriteString(tok.Data)
+				b.WriteByte(' ')
+			}
+		}
+	}
+
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+// insideSVG перевіряє, чи знаходиться елемент усередині <svg>
+func insideSVG(el *Element) bool {
+	for p := el.Parent; p != nil; p = p.Parent {
+		if p.Tag == "svg" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package htmldoc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Selector — спрощений CSS-селектор одного елемента: tag, #id, .class,
+// [attr] та [attr=value] у будь-якому поєднанні, наприклад div.content#main
+type Selector struct {
+	Tag     string
+	ID      string
+	Classes []string
+	Attrs   []Attribute // Для [attr] перевіряється лише наявність атрибута
+	hasVal  []bool      // Чи задано значення для відповідного атрибута
+}
+
+// ParseSelector розбирає селектор; комбінатори (пробіл, >, +) не підтримуються
+func ParseSelector(s string) (*Selector, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, fmt.Errorf("порожній селектор")
+	}
+	if strings.ContainsAny(s, " \t>+~,") {
+		return nil, fmt.Errorf("комбінатори не підтримуються: %q", s)
+	}
+
+	sel := &Selector{}
+	i := 0
+	readIdent := func() string {
+		start := i
+		for i < len(s) && s[i] != '.' && s[i] != '#' && s[i] != '[' {
+			i++
+		}
+		return s[start:i]
+	}
+
+	if s[0] != '.' && s[0] != '#' && s[0] != '[' {
+		sel.Tag = strings.ToLower(readIdent())
+	}
+	for i < len(s) {
+		switch s[i] {
+		case '#':
+			i++
+			if sel.ID = readIdent(); sel.ID == "" {
+				return nil, fmt.Errorf("порожній id у селекторі %q", s)
+			}
+		case '.':
+			i++
+			class := readIdent()
+			if class == "" {
+				return nil, fmt.Errorf("порожній клас у селекторі %q", s)
+			}
+			sel.Classes = append(sel.Classes, class)
+		case '[':
+			end := strings.IndexByte(s[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("незакритий атрибут у селекторі %q", s)
+			}
+			key, val, hasVal := strings.Cut(s[i+1:i+end], "=")
+			key = strings.ToLower(strings.TrimSpace(key))
+			if key == "" {
+				return nil, fmt.Errorf("порожній атрибут у селекторі %q", s)
+			}
+			sel.Attrs = append(sel.Attrs, Attribute{Key: key, Val: strings.Trim(strings.TrimSpace(val), `"'`)})
+			sel.hasVal = append(sel.hasVal, hasVal)
+			i += end + 1
+		default:
+			return nil, fmt.Errorf("некоректний селектор %q", s)
+		}
+	}
+
+	return sel, nil
+}
+
+// Match перевіряє, чи відповідає елемент селектору
+func (s *Selector) Match(el *Element) bool {
+	if s.Tag != "" && el.Tag != s.Tag {
+		return false
+	}
+	if s.ID != "" && el.Attr("id") != s.ID {
+		return false
+	}
+	for _, class := range s.Classes {
+		if !el.HasToken("class", class) {
+			return false
+		}
+	}
+	for i, attr := range s.Attrs {
+		val, ok := el.LookupAttr(attr.Key)
+		if !ok || (s.hasVal[i] && val != attr.Val) {
+			return false
+		}
+	}
+	return true
+}
+
+// Select повертає перший елемент, що відповідає селектору, або nil
+func (d *Document) Select(sel *Selector) *Element {
+	for _, el := range d.Elements {
+		if sel.Match(el) {
+			return el
+		}
+	}
+	return nil
+}
@@ -0,0 +1,279 @@
+package htmldoc
+
+import (
+	"html"
+	"strings"
+)
+
+// TokenType — тип токена HTML
+type TokenType int
+
+const (
+	TextToken TokenType = iota
+	StartTagToken
+	EndTagToken
+	SelfClosingTagToken
+	CommentToken
+	DoctypeToken
+)
+
+// Attribute — атрибут тегу з декодованим значенням
+type Attribute struct {
+	Key string // Ім'я в нижньому регістрі
+	Val string // Значення з декодованими HTML-сутностями
+}
+
+// Token — лексема HTML-документа
+type Token struct {
+	Type  TokenType
+	Data  string // Ім'я тегу в нижньому регістрі або текст
+	Attr  []Attribute
+	Start int // Зміщення початку токена в документі
+	End   int // Зміщення кінця токена в документі
+}
+
+// rawTextElements — елементи, вміст яких не розбирається як розмітка
+var rawTextElements = map[string]bool{
+	"script":   true,
+	"style":    true,
+	"title":    true,
+	"textarea": true,
+}
+
+// escapableRawText — елементи, у вмісті яких декодуються HTML-сутності
+var escapableRawText = map[string]bool{
+	"title":    true,
+	"textarea": true,
+}
+
+// Tokenizer розбиває HTML на лексеми
+type Tokenizer struct {
+	src    string
+	pos    int
+	rawTag string // Тег, вміст якого читається як сирий текст
+}
+
+// NewTokenizer створює Tokenizer для вказаного документа
+func NewTokenizer(src string) *Tokenizer {
+	return &Tokenizer{src: src}
+}
+
+// Next повертає наступну лексему; false — кінець документа
+func (t *Tokenizer) Next() (Token, bool) {
+	if t.pos >= len(t.src) {
+		return Token{}, false
+	}
+
+	if t.rawTag != "" {
+		return t.readRawText(), true
+	}
+
+	if t.src[t.pos] == '<' {
+		if tok, ok := t.readMarkup(); ok {
+			return tok, true
+		}
+	}
+
+	return t.readText(), true
+}
+
+// readText читає текст до наступної розмітки
+func (t *Tokenizer) readText() Token {
+	start := t.pos
+	i := t.pos + 1
+	for i < len(t.src) {
+		if t.src[i] == '<' && i+1 < len(t.src) && isMarkupStart(t.src[i+1]) {
+			break
+		}
+		i++
+	}
+	t.pos = i
+	return Token{Type: TextToken, Data: html.UnescapeString(t.src[start:i]), Start: start, End: i}
+}
+
+// readRawText читає вміст script/style/title/textarea до закриваючого тегу
+func (t *Tokenizer) readRawText() Token {
+	start := t.pos
+	end := indexFold(t.src[start:], "</"+t.rawTag)
+	if end < 0 {
+		end = len(t.src)
+	} else {
+		end += start
+	}
+
+	data := t.src[start:end]
+	if escapableRawText[t.rawTag] {
+		data = html.UnescapeString(data)
+	}
+
+	t.pos = end
+	t.rawTag = ""
+	return Token{Type: TextToken, Data: data, Start: start, End: end}
+}
+
+// readMarkup читає тег, коментар або doctype, що починається з '<'
+func (t *Tokenizer) readMarkup() (Token, bool) {
+	start := t.pos
+	rest := t.src[start:]
+
+	switch {
+	case strings.HasPrefix(rest, "<!--"):
+		end := strings.Index(rest[4:], "-->")
+		if end < 0 {
+			t.pos = len(t.src)
+			return Token{Type: CommentToken, Data: rest[4:], Start: start, End: t.pos}, true
+		}
+		t.pos = start + 4 + end + 3
+		return Token{Type: CommentToken, Data: rest[4 : 4+end], Start: start, End: t.pos}, true
+	case len(rest) > 1 && (rest[1] == '!' || rest[1] == '?'):
+		// Незакрита розмітка (наприклад, "<!" у кінці документа) триває до кінця
+		end := strings.IndexByte(rest, '>')
+		next := end + 1
+		if end < 0 {
+			end, next = len(rest), len(rest)
+		}
+		t.pos = start + next
+		data := rest[2:end]
+		if len(data) >= 7 && strings.EqualFold(data[:7], "doctype") {
+			return Token{Type: DoctypeToken, Data: strings.TrimSpace(data[7:]), Start: start, End: t.pos}, true
+		}
+		return Token{Type: CommentToken, Data: data, Start: start, End: t.pos}, true
+	case len(rest) > 2 && rest[1] == '/' && isLetter(rest[2]):
+		name, i := readName(rest, 2)
+		end := strings.IndexByte(rest[i:], '>')
+		if end < 0 {
+			t.pos = len(t.src)
+		} else {
+			t.pos = start + i + end + 1
+		}
+		return Token{Type: EndTagToken, Data: name, Start: start, End: t.pos}, true
+	case len(rest) > 1 && isLetter(rest[1]):
+		return t.readStartTag(), true
+	}
+
+	return Token{}, false
+}
+
+// readStartTag читає відкриваючий тег з атрибутами
+func (t *Tokenizer) readStartTag() Token {
+	start := t.pos
+	src := t.src
+	name, i := readName(src, start+1)
+	tok := Token{Type: StartTagToken, Data: name, Start: start}
+
+	for i < len(src) {
+		i = skipSpace(src, i)
+		if i >= len(src) {
+			break
+		}
+		if src[i] == '>' {
+			i++
+			break
+		}
+		if src[i] == '/' {
+			if i+1 < len(src) && src[i+1] == '>' {
+				tok.Type = SelfClosingTagToken
+				i += 2
+				break
+			}
+			i++
+			continue
+		}
+
+		// Ім'я атрибута
+		keyStart := i
+		for i < len(src) && !isSpace(src[i]) && src[i] != '=' && src[i] != '>' && !(src[i] == '/' && i+1 < len(src) && src[i+1] == '>') {
+			i++
+		}
+		key := strings.ToLower(src[keyStart:i])
+		if i == keyStart {
+			i++ // Некоректний символ — пропускаємо
+			continue
+		}
+
+		// Значення атрибута
+		val := ""
+		j := skipSpace(src, i)
+		if j < len(src) && src[j] == '=' {
+			j = skipSpace(src, j+1)
+			if j < len(src) && (src[j] == '"' || src[j] == '\'') {
+				quote := src[j]
+				end := strings.IndexByte(src[j+1:], quote)
+				if end < 0 {
+					val = src[j+1:]
+					i = len(src)
+				} else {
+					val = src[j+1 : j+1+end]
+					i = j + 1 + end + 1
+				}
+			} else {
+				valStart := j
+				for j < len(src) && !isSpace(src[j]) && src[j] != '>' {
+					j++
+				}
+				val = src[valStart:j]
+				i = j
+			}
+		}
+
+		// За специфікацією HTML дублікати атрибутів ігноруються
+		if !hasAttr(tok.Attr, key) {
+			tok.Attr = append(tok.Attr, Attribute{Key: key, Val: html.UnescapeString(val)})
+		}
+	}
+
+	t.pos = i
+	tok.End = i
+	if tok.Type == StartTagToken && rawTextElements[name] {
+		t.rawTag = name
+	}
+	return tok
+}
+
+// readName читає ім'я тегу, починаючи з позиції i
+func readName(s string, i int) (string, int) {
+	start := i
+	for i < len(s) && !isSpace(s[i]) && s[i] != '>' && s[i] != '/' {
+		i++
+	}
+	return strings.ToLower(s[start:i]), i
+}
+
+func hasAttr(attrs []Attribute, key string) bool {
+	for _, a := range attrs {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func skipSpace(s string, i int) int {
+	for i < len(s) && isSpace(s[i]) {
+		i++
+	}
+	return i
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+func isLetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+func isMarkupStart(c byte) bool {
+	return isLetter(c) || c == '/' || c == '!' || c == '?'
+}
+
+// indexFold шукає substr у s без урахування регістру (substr — ASCII)
+func indexFold(s, substr string) int {
+	n := len(substr)
+	for i := 0; i+n <= len(s); i++ {
+		if strings.EqualFold(s[i:i+n], substr) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,149 @@
+package htmldoc
+
+import (
+	"reflect"
+	"testing"
+)
+
+// tokenize повертає всі лексеми документа
+func tokenize(t *testing.T, src string) []Token {
+	t.Helper()
+	var tokens []Token
+	tz := NewTokenizer(src)
+	for {
+		tok, ok := tz.Next()
+		if !ok {
+			return tokens
+		}
+		if tok.Start > tok.End || tok.End > len(src) {
+			t.Fatalf("некоректні межі лексеми %+v для %q", tok, src)
+		}
+		tokens = append(tokens, tok)
+	}
+}
+
+// brief — тип і дані лексеми для порівняння в тестах
+type brief struct {
+	Type TokenType
+	Data string
+}
+
+func briefs(tokens []Token) []brief {
+	result := make([]brief, 0, len(tokens))
+	for _, tok := range tokens {
+		result = append(result, brief{tok.Type, tok.Data})
+	}
+	return result
+}
+
+func TestTokenizerTruncatedMarkup(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []brief
+	}{
+		{"порожній документ", "", []brief{}},
+		{"< у кінці", "text<", []brief{{TextToken, "text<"}}},
+		{"лише <", "<", []brief{{TextToken, "<"}}},
+		{"<! у кінці", "a<!", []brief{{TextToken, "a"}, {CommentToken, ""}}},
+		{"<? у кінці", "a<?", []brief{{TextToken, "a"}, {CommentToken, ""}}},
+		{"незакритий <!x", "<!x", []brief{{CommentToken, "x"}}},
+		{"незакритий doctype", "<!DOCTYPE html", []brief{{DoctypeToken, "html"}}},
+		{"</ у кінці", "a</", []brief{{TextToken, "a"}, {TextToken, "</"}}},
+		{"незакритий кінцевий тег", "<p>a</p", []brief{{StartTagToken, "p"}, {TextToken, "a"}, {EndTagToken, "p"}}},
+		{"незакритий початковий тег", `<a href="x`, []brief{{StartTagToken, "a"}}},
+		{"тег без атрибутів у кінці", "<a", []brief{{StartTagToken, "a"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := briefs(tokenize(t, tt.src))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("лексеми %q = %v, очікувалось %v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizerComments(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []brief
+	}{
+		{"коментар", "<!-- x -->a", []brief{{CommentToken, " x "}, {TextToken, "a"}}},
+		{"незакритий коментар", "a<!-- x <p>", []brief{{TextToken, "a"}, {CommentToken, " x <p>"}}},
+		{"коментар без вмісту у кінці", "<!--", []brief{{CommentToken, ""}}},
+		{"теги всередині коментаря", "<!--<a href=x>-->", []brief{{CommentToken, "<a href=x>"}}},
+		// Поза SVG і MathML CDATA — некоректний коментар до першого '>'
+		{"CDATA", "<![CDATA[x]]>a", []brief{{CommentToken, "[CDATA[x]]"}, {TextToken, "a"}}},
+		{"незакритий CDATA", "<![CDATA[x", []brief{{CommentToken, "[CDATA[x"}}},
+		{"інструкція обробки", `<?xml version="1.0"?>`, []brief{{CommentToken, `xml version="1.0"?`}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := briefs(tokenize(t, tt.src))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("лексеми %q = %v, очікувалось %v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizerRawText(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []brief
+	}{
+		{"script", "<script>a<b</script>", []brief{{StartTagToken, "script"}, {TextToken, "a<b"}, {EndTagToken, "script"}}},
+		{"script у кінці документа", "<script>if (a<b) {", []brief{{StartTagToken, "script"}, {TextToken, "if (a<b) {"}}},
+		{"порожній script у кінці", "<script>", []brief{{StartTagToken, "script"}}},
+		{"закриваючий тег у іншому регістрі", "<style>p{}</STYLE>", []brief{{StartTagToken, "style"}, {TextToken, "p{}"}, {EndTagToken, "style"}}},
+		{"сутності в title", "<title>a &amp; b", []brief{{StartTagToken, "title"}, {TextToken, "a & b"}}},
+		{"сутності в script не декодуються", "<script>a &amp; b</script>", []brief{{StartTagToken, "script"}, {TextToken, "a &amp; b"}, {EndTagToken, "script"}}},
+		{"незакритий textarea з розміткою", "<textarea><p>x", []brief{{StartTagToken, "textarea"}, {TextToken, "<p>x"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := briefs(tokenize(t, tt.src))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("лексеми %q = %v, очікувалось %v", tt.src, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizerAttributes(t *testing.T) {
+	tokens := tokenize(t, `<a HREF="/x?a=1&amp;b=2" data-x='y' checked href="/dup" rel=nofollow />`)
+	if len(tokens) != 1 || tokens[0].Type != SelfClosingTagToken {
+		t.Fatalf("очікувався один самозакритий тег, отримано %+v", tokens)
+	}
+	want := []Attribute{
+		{Key: "href", Val: "/x?a=1&b=2"},
+		{Key: "data-x", Val: "y"},
+		{Key: "checked", Val: ""},
+		{Key: "rel", Val: "nofollow"},
+	}
+	if !reflect.DeepEqual(tokens[0].Attr, want) {
+		t.Errorf("атрибути = %+v, очікувалось %+v", tokens[0].Attr, want)
+	}
+}
+
+// TestParseTruncatedDocuments перевіряє, що розбір обрізаних документів не панікує
+func TestParseTruncatedDocuments(t *testing.T) {
+	for _, src := range []string{
+		"<html><body>text<!", "<html><body>text<?", "<html><body><", "<!-", "<!--",
+		"<title>x", "<head><script>", "<a href='x", "<p>a</", "<![CDATA[",
+	} {
+		doc := Parse([]byte(src))
+		for _, el := range doc.Elements {
+			if el.Start > el.End || el.End > len(src) {
+				t.Errorf("некоректні межі елемента %s у %q: %d..%d", el.Tag, src, el.Start, el.End)
+			}
+		}
+		_ = doc.Text()
+	}
+}
@@ -0,0 +1,176 @@
+package linkgraph
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WriteDOT записує граф у форматі Graphviz DOT
+func (g *Graph) WriteDOT(w io.Writer) error {
+	b := bufio.NewWriter(w)
+	fmt.Fprintln(b, "digraph links {")
+	fmt.Fprintln(b, "  node [shape=box];")
+
+	for i, n := range g.Nodes {
+		attrs := []string{
+			"label=" + dotQuote(n.URL),
+			"status=" + strconv.Itoa(n.StatusCode),
+			"indexable=" + strconv.FormatBool(n.Indexable),
+			"in_sitemap=" + strconv.FormatBool(n.InSitemap),
+			"pagerank=" + strconv.FormatFloat(n.PageRank, 'g', 6, 64),
+		}
+		if n.Depth != nil {
+			attrs = append(attrs, "depth="+strconv.Itoa(*n.Depth))
+		}
+		switch {
+		case n.StatusCode >= 400 || n.StatusCode == 0:
+			attrs = append(attrs, "color=red")
+		case !n.Indexable:
+			attrs = append(attrs, "color=gray")
+		}
+		fmt.Fprintf(b, "  n%d [%s];\n", i, strings.Join(attrs, ", "))
+	}
+
+	for _, e := range g.Edges {
+		attrs := []string{"label=" + dotQuote(e.Anchor)}
+		if e.Nofollow {
+			attrs = append(attrs, "nofollow=true", "style=dashed")
+		}
+		fmt.Fprintf(b, "  n%d -> n%d [%s];\n", e.From, e.To, strings.Join(attrs, ", "))
+	}
+
+	fmt.Fprintln(b, "}")
+	return b.Flush()
+}
+
+// dotQuote повертає рядок у лапках з екрануванням для DOT
+func dotQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// Структури GraphML
+type graphML struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+type graphMLKey struct {
+	ID   string `xml:"id,attr"`
+	For  string `xml:"for,attr"`
+	Name string `xml:"attr.name,attr"`
+	Type string `xml:"attr.type,attr"`
+}
+
+type graphMLGraph struct {
+	ID          string        `xml:"id,attr"`
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteGraphML записує граф у форматі GraphML
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	doc := graphML{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys: []graphMLKey{
+			{ID: "url", For: "node", Name: "url", Type: "string"},
+			{ID: "status", For: "node", Name: "status", Type: "int"},
+			{ID: "indexable", For: "node", Name: "indexable", Type: "boolean"},
+			{ID: "in_sitemap", For: "node", Name: "in_sitemap", Type: "boolean"},
+			{ID: "depth", For: "node", Name: "depth", Type: "int"},
+			{ID: "pagerank", For: "node", Name: "pagerank", Type: "double"},
+			{ID: "anchor", For: "edge", Name: "anchor", Type: "string"},
+			{ID: "nofollow", For: "edge", Name: "nofollow", Type: "boolean"},
+		},
+		Graph: graphMLGraph{ID: "links", EdgeDefault: "directed"},
+	}
+
+	for i, n := range g.Nodes {
+		node := graphMLNode{ID: "n" + strconv.Itoa(i), Data: []graphMLData{
+			{Key: "url", Value: n.URL},
+			{Key: "status", Value: strconv.Itoa(n.StatusCode)},
+			{Key: "indexable", Value: strconv.FormatBool(n.Indexable)},
+			{Key: "in_sitemap", Value: strconv.FormatBool(n.InSitemap)},
+			{Key: "pagerank", Value: strconv.FormatFloat(n.PageRank, 'g', -1, 64)},
+		}}
+		if n.Depth != nil {
+			node.Data = append(node.Data, graphMLData{Key: "depth", Value: strconv.Itoa(*n.Depth)})
+		}
+		doc.Graph.Nodes = append(doc.Graph.Nodes, node)
+	}
+	for _, e := range g.Edges {
+		doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+			Source: "n" + strconv.Itoa(e.From),
+			Target: "n" + strconv.Itoa(e.To),
+			Data: []graphMLData{
+				{Key: "anchor", Value: e.Anchor},
+				{Key: "nofollow", Value: strconv.FormatBool(e.Nofollow)},
+			},
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// jsonLink — вихідне посилання у форматі списку суміжності
+type jsonLink struct {
+	To       string `json:"to"`
+	Anchor   string `json:"anchor"`
+	Nofollow bool   `json:"nofollow"`
+}
+
+// WriteJSON записує граф як список вузлів і списки суміжності за URL
+func (g *Graph) WriteJSON(w io.Writer) error {
+	adjacency := make(map[string][]jsonLink, len(g.Nodes))
+	for _, n := range g.Nodes {
+		adjacency[n.URL] = make([]jsonLink, 0)
+	}
+	for _, e := range g.Edges {
+		from := g.Nodes[e.From].URL
+		adjacency[from] = append(adjacency[from], jsonLink{To: g.Nodes[e.To].URL, Anchor: e.Anchor, Nofollow: e.Nofollow})
+	}
+
+	nodes := g.Nodes
+	if nodes == nil {
+		nodes = make([]Node, 0)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Nodes     []Node                `json:"nodes"`
+		Adjacency map[string][]jsonLink `json:"adjacency"`
+	}{nodes, adjacency})
+}
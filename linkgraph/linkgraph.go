@@ -0,0 +1,125 @@
+// Package linkgraph описує граф внутрішніх посилань сайту, обчислює PageRank
+// і експортує граф у форматах Graphviz DOT, GraphML та JSON
+package linkgraph
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Формати експорту
+const (
+	FormatDOT     = "dot"
+	FormatGraphML = "graphml"
+	FormatJSON    = "json"
+)
+
+// Node — сторінка в графі
+type Node struct {
+	URL        string  `json:"url"`
+	StatusCode int     `json:"status_code"`
+	Indexable  bool    `json:"indexable"`
+	InSitemap  bool    `json:"in_sitemap"`
+	Depth      *int    `json:"depth,omitempty"` // Глибина кліків від головної; nil — недосяжна
+	PageRank   float64 `json:"pagerank"`
+}
+
+// Edge — посилання між сторінками; From і To — індекси вузлів
+type Edge struct {
+	From     int
+	To       int
+	Anchor   string
+	Nofollow bool
+}
+
+// Graph — граф внутрішніх посилань
+type Graph struct {
+	Nodes []Node
+	Edges []Edge
+}
+
+// Параметри PageRank
+const (
+	damping    = 0.85
+	iterations = 50
+)
+
+// ComputePageRank обчислює PageRank вузлів за посиланнями без nofollow;
+// сума значень дорівнює 1
+func (g *Graph) ComputePageRank() {
+	n := len(g.Nodes)
+	if n == 0 {
+		return
+	}
+
+	outgoing := make([][]int, n)
+	for _, e := range g.Edges {
+		if !e.Nofollow && e.From != e.To {
+			outgoing[e.From] = append(outgoing[e.From], e.To)
+		}
+	}
+
+	rank := make([]float64, n)
+	for i := range rank {
+		rank[i] = 1 / float64(n)
+	}
+
+	next := make([]float64, n)
+	for iter := 0; iter < iterations; iter++ {
+		// Вага сторінок без вихідних посилань розподіляється рівномірно
+		var dangling float64
+		for i, out := range outgoing {
+			if len(out) == 0 {
+				dangling += rank[i]
+			}
+		}
+
+		base := (1-damping)/float64(n) + damping*dangling/float64(n)
+		for i := range next {
+			next[i] = base
+		}
+		for i, out := range outgoing {
+			share := damping * rank[i] / float64(len(out))
+			for _, j := range out {
+				next[j] += share
+			}
+		}
+		rank, next = next, rank
+	}
+
+	for i := range g.Nodes {
+		g.Nodes[i].PageRank = rank[i]
+	}
+}
+
+// Write записує граф у вказаному форматі
+func (g *Graph) Write(w io.Writer, format string) error {
+	switch format {
+	case FormatDOT:
+		return g.WriteDOT(w)
+	case FormatGraphML:
+		return g.WriteGraphML(w)
+	case FormatJSON:
+		return g.WriteJSON(w)
+	default:
+		return fmt.Errorf("невідомий формат графа: %s", format)
+	}
+}
+
+// ParseFormats розбирає список форматів через кому
+func ParseFormats(s string) ([]string, error) {
+	var formats []string
+	for _, f := range strings.Split(s, ",") {
+		f = strings.ToLower(strings.TrimSpace(f))
+		switch f {
+		case "":
+			continue
+		case FormatDOT, FormatGraphML, FormatJSON:
+			formats = append(formats, f)
+		default:
+			return nil, fmt.Errorf("невідомий формат графа: %s", f)
+		}
+	}
+	return formats, nil
+}
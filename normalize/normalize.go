@@ -0,0 +1,69 @@
+// Package normalize нормалізує HTML сторінки перед обчисленням хешу вмісту,
+// щоб динамічні фрагменти (nonce, ідентифікатори збірки, час) не приховували дублі
+package normalize
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"sitemap-checker/htmldoc"
+)
+
+// Pipeline — послідовність кроків нормалізації: вибір області, видалення
+// фрагментів за регулярними виразами та стискання пробілів
+type Pipeline struct {
+	Region         *htmldoc.Selector // Область документа для хешування; nil — весь документ
+	Strip          []*regexp.Regexp  // Фрагменти, що видаляються
+	CollapseSpaces bool              // Замінювати послідовності пробільних символів одним пробілом
+}
+
+// Parse створює Pipeline з налаштувань: region — селектор області,
+// patterns — регулярні вирази, по одному в рядку
+func Parse(region, patterns string, collapseSpaces bool) (*Pipeline, error) {
+	p := &Pipeline{CollapseSpaces: collapseSpaces}
+
+	if strings.TrimSpace(region) != "" {
+		sel, err := htmldoc.ParseSelector(region)
+		if err != nil {
+			return nil, err
+		}
+		p.Region = sel
+	}
+
+	for _, pattern := range strings.Split(patterns, "\n") {
+		if pattern = strings.TrimSpace(pattern); pattern == "" {
+			continue
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("невірний регулярний вираз %q: %v", pattern, err)
+		}
+		p.Strip = append(p.Strip, re)
+	}
+
+	return p, nil
+}
+
+// Apply повертає нормалізований вміст документа; якщо області немає
+// в документі, нормалізується весь документ
+func (p *Pipeline) Apply(doc *htmldoc.Document) string {
+	content := doc.Source
+	if p == nil {
+		return content
+	}
+
+	if p.Region != nil {
+		if el := doc.Select(p.Region); el != nil {
+			content = doc.InnerHTML(el)
+		}
+	}
+	for _, re := range p.Strip {
+		content = re.ReplaceAllString(content, "")
+	}
+	if p.CollapseSpaces {
+		content = strings.Join(strings.Fields(content), " ")
+	}
+
+	return content
+}
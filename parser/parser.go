@@ -9,6 +9,7 @@ import (
 type URLSet struct {
 	XMLName xml.Name `xml:"urlset"`
 	URLs    []URL    `xml:"url"`
+	Source  string   `xml:"-"` // URL файлу sitemap, з якого завантажено набір
 }
 
 // SitemapIndex представляє <sitemapindex> у sitemap.xml
@@ -0,0 +1,209 @@
+package rewrite
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// HostRule підміняє хост (і, за потреби, схему) URL
+type HostRule struct {
+	FromScheme string // Схема, до якої застосовується правило (порожня — будь-яка)
+	FromHost   string // Хост, який потрібно замінити
+	ToScheme   string // Нова схема (порожня — без змін)
+	ToHost     string // Новий хост
+}
+
+// PathRule підміняє префікс шляху URL
+type PathRule struct {
+	FromPrefix string // Префікс шляху, який потрібно замінити
+	ToPrefix   string // Новий префікс шляху
+}
+
+// Rules містить набір правил переписування URL
+type Rules struct {
+	Hosts []HostRule
+	Paths []PathRule
+	Site  string // Хост сайту; правила для шляхів діють лише на нього та на хости з правил для хостів
+}
+
+// Parse розбирає правила з рядків виду from=to,from=to; siteURL — адреса
+// сайту (sitemap), до хоста якої застосовуються правила для шляхів
+func Parse(hosts, paths, siteURL string) (*Rules, error) {
+	rules := &Rules{}
+	if u, err := url.Parse(siteURL); err == nil {
+		rules.Site = strings.ToLower(u.Host)
+	}
+
+	hostPairs, err := splitPairs(hosts)
+	if err != nil {
+		return nil, err
+	}
+	for _, pair := range hostPairs {
+		fromScheme, fromHost := splitScheme(pair[0])
+		toScheme, toHost := splitScheme(pair[1])
+		rules.Hosts = append(rules.Hosts, HostRule{
+			FromScheme: fromScheme,
+			FromHost:   strings.ToLower(fromHost),
+			ToScheme:   toScheme,
+			ToHost:     strings.ToLower(toHost),
+		})
+	}
+
+	pathPairs, err := splitPairs(paths)
+	if err != nil {
+		return nil, err
+	}
+	for _, pair := range pathPairs {
+		if !strings.HasPrefix(pair[0], "/") || !strings.HasPrefix(pair[1], "/") {
+			return nil, fmt.Errorf("префікс шляху має починатися з '/': %s=%s", pair[0], pair[1])
+		}
+		rules.Paths = append(rules.Paths, PathRule{FromPrefix: pair[0], ToPrefix: pair[1]})
+	}
+
+	return rules, nil
+}
+
+// Empty повертає true, якщо правил немає
+func (r *Rules) Empty() bool {
+	return r == nil || (len(r.Hosts) == 0 && len(r.Paths) == 0)
+}
+
+// Apply переписує оригінальний URL за першим відповідним правилом для хоста та
+// для шляху. Застосовувати його слід один раз, безпосередньо перед завантаженням:
+// повторне застосування до переписаного URL (наприклад, REWRITE_PATHS=/=/preview/)
+// дає /preview/preview/
+func (r *Rules) Apply(rawURL string) string {
+	if r.Empty() {
+		return rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+
+	// Правила для шляхів стосуються лише сайту: сторонні хости (CDN, зображення
+	// з інших доменів) завантажуються як є
+	site := r.siteHost(u.Host)
+	for _, rule := range r.Hosts {
+		if rule.FromScheme != "" && rule.FromScheme != u.Scheme {
+			continue
+		}
+		if strings.ToLower(u.Host) != rule.FromHost {
+			continue
+		}
+		u.Host = rule.ToHost
+		if rule.ToScheme != "" {
+			u.Scheme = rule.ToScheme
+		}
+		site = true
+		break
+	}
+	if !site {
+		return u.String()
+	}
+
+	for _, rule := range r.Paths {
+		if strings.HasPrefix(u.Path, rule.FromPrefix) {
+			u.Path = rule.ToPrefix + strings.TrimPrefix(u.Path, rule.FromPrefix)
+			u.RawPath = ""
+			break
+		}
+	}
+
+	return u.String()
+}
+
+// Restore повертає переписаний URL до вихідного вигляду (зворотне до Apply);
+// адреси, яких правила не стосуються, повертаються без змін. Відновлення
+// неоднозначне (справжня адреса /news/x за правила /blog/=/news/ стане /blog/x),
+// тому застосовувати його слід лише до адрес, отриманих від переписаного сайту,
+// наприклад до редіректів. Якщо правило для хоста змінює схему, а вихідну схему
+// не вказано, схема не відновлюється
+func (r *Rules) Restore(rawURL string) string {
+	if r.Empty() {
+		return rawURL
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return rawURL
+	}
+
+	site := r.siteHost(u.Host)
+	for _, rule := range r.Hosts {
+		if rule.ToScheme != "" && rule.ToScheme != u.Scheme {
+			continue
+		}
+		if strings.ToLower(u.Host) != rule.ToHost {
+			continue
+		}
+		u.Host = rule.FromHost
+		if rule.FromScheme != "" {
+			u.Scheme = rule.FromScheme
+		}
+		site = true
+		break
+	}
+	if !site {
+		return u.String()
+	}
+
+	for _, rule := range r.Paths {
+		if strings.HasPrefix(u.Path, rule.ToPrefix) {
+			u.Path = rule.FromPrefix + strings.TrimPrefix(u.Path, rule.ToPrefix)
+			u.RawPath = ""
+			break
+		}
+	}
+
+	return u.String()
+}
+
+// siteHost повідомляє, чи є хост хостом сайту, до якого застосовуються правила для шляхів
+func (r *Rules) siteHost(host string) bool {
+	host = strings.ToLower(host)
+	if host == r.Site {
+		return true
+	}
+	for _, rule := range r.Hosts {
+		if host == rule.FromHost {
+			return true
+		}
+	}
+	return false
+}
+
+// Equal порівнює два оригінальні URL після застосування правил до кожного з них
+func (r *Rules) Equal(a, b string) bool {
+	return r.Apply(a) == r.Apply(b)
+}
+
+// splitPairs розбирає рядок виду from=to,from=to
+func splitPairs(s string) ([][2]string, error) {
+	var pairs [][2]string
+	if strings.TrimSpace(s) == "" {
+		return pairs, nil
+	}
+
+	for _, pair := range strings.Split(s, ",") {
+		from, to, ok := strings.Cut(pair, "=")
+		from = strings.TrimSpace(from)
+		to = strings.TrimSpace(to)
+		if !ok || from == "" || to == "" {
+			return nil, fmt.Errorf("невірне правило переписування %q", pair)
+		}
+		pairs = append(pairs, [2]string{from, to})
+	}
+
+	return pairs, nil
+}
+
+// splitScheme відокремлює необов'язкову схему від хоста
+func splitScheme(s string) (string, string) {
+	if scheme, host, ok := strings.Cut(s, "://"); ok {
+		return strings.ToLower(scheme), strings.TrimSuffix(host, "/")
+	}
+	return "", strings.TrimSuffix(s, "/")
+}
@@ -0,0 +1,118 @@
+package rewrite
+
+import (
+	"reflect"
+	"testing"
+)
+
+// siteURL — адреса sitemap, до хоста якої застосовуються правила для шляхів
+const siteURL = "https://example.com/sitemap.xml"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		hosts   string
+		paths   string
+		want    *Rules
+		wantErr bool
+	}{
+		{"без правил", "", "", &Rules{Site: "example.com"}, false},
+		{
+			"хост зі схемою", "https://Example.com=http://staging.local/", "",
+			&Rules{Hosts: []HostRule{{FromScheme: "https", FromHost: "example.com", ToScheme: "http", ToHost: "staging.local"}}, Site: "example.com"},
+			false,
+		},
+		{
+			"кілька правил", "a.com=b.com, c.com=d.com", "/=/preview/",
+			&Rules{
+				Hosts: []HostRule{{FromHost: "a.com", ToHost: "b.com"}, {FromHost: "c.com", ToHost: "d.com"}},
+				Paths: []PathRule{{FromPrefix: "/", ToPrefix: "/preview/"}},
+				Site:  "example.com",
+			},
+			false,
+		},
+		{"правило без =", "a.com", "", nil, true},
+		{"порожня права частина", "a.com=", "", nil, true},
+		{"шлях без /", "", "blog=/news", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.hosts, tt.paths, siteURL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse(%q, %q) помилка = %v, очікувалась помилка: %v", tt.hosts, tt.paths, err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q, %q) = %+v, очікувалось %+v", tt.hosts, tt.paths, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	tests := []struct {
+		name      string
+		hosts     string
+		paths     string
+		original  string
+		rewritten string
+	}{
+		{"без правил", "", "", "https://example.com/a", "https://example.com/a"},
+		{"хост", "example.com=staging.local", "", "https://example.com/a?x=1", "https://staging.local/a?x=1"},
+		{"хост у верхньому регістрі", "example.com=staging.local", "", "https://EXAMPLE.com/a", "https://staging.local/a"},
+		{"хост і схема", "https://example.com=http://staging.local", "", "https://example.com/a", "http://staging.local/a"},
+		{"схема не збігається", "https://example.com=http://staging.local", "", "http://example.com/a", "http://example.com/a"},
+		{"інший хост", "example.com=staging.local", "", "https://other.com/a", "https://other.com/a"},
+		{"префікс шляху", "", "/blog/=/news/", "https://example.com/blog/post", "https://example.com/news/post"},
+		{"кореневий префікс", "", "/=/preview/", "https://example.com/a/b", "https://example.com/preview/a/b"},
+		{"хост і шлях", "example.com=staging.local", "/=/preview/", "https://example.com/", "https://staging.local/preview/"},
+		{"шлях на сторонньому хості", "", "/=/preview/", "https://cdn.other.com/app.js", "https://cdn.other.com/app.js"},
+		// Справжня адреса з префіксом призначення не вважається вже переписаною
+		{"справжня адреса з префіксом призначення", "", "/blog/=/news/", "https://example.com/news/x", "https://example.com/news/x"},
+		{"справжня адреса під кореневим префіксом", "", "/=/preview/", "https://example.com/preview/a", "https://example.com/preview/preview/a"},
+		{"шлях на хості з правила", "www.example.com=staging.local", "/=/preview/", "https://www.example.com/a", "https://staging.local/preview/a"},
+		{"шлях на сторонньому хості з правилом для хоста", "example.com=staging.local", "/=/preview/", "https://cdn.other.com/a.png", "https://cdn.other.com/a.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := Parse(tt.hosts, tt.paths, siteURL)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got := rules.Apply(tt.original); got != tt.rewritten {
+				t.Errorf("Apply(%q) = %q, очікувалось %q", tt.original, got, tt.rewritten)
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	tests := []struct {
+		name  string
+		hosts string
+		paths string
+		url   string
+		want  string
+	}{
+		{"хост", "example.com=staging.local", "", "https://staging.local/a", "https://example.com/a"},
+		{"хост і схема", "https://example.com=http://staging.local", "", "http://staging.local/a", "https://example.com/a"},
+		{"кореневий префікс", "", "/=/preview/", "https://example.com/preview/a", "https://example.com/a"},
+		{"хост і шлях", "example.com=staging.local", "/=/preview/", "https://staging.local/preview/", "https://example.com/"},
+		{"непереписаний URL", "example.com=staging.local", "/blog/=/news/", "https://other.com/blog/a", "https://other.com/blog/a"},
+		{"шлях на сторонньому хості", "", "/=/preview/", "https://cdn.other.com/preview/a", "https://cdn.other.com/preview/a"},
+		{"відносний URL", "example.com=staging.local", "", "/a", "/a"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := Parse(tt.hosts, tt.paths, siteURL)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got := rules.Restore(tt.url); got != tt.want {
+				t.Errorf("Restore(%q) = %q, очікувалось %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
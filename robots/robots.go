@@ -0,0 +1,388 @@
+package robots
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// MaxSize — обсяг robots.txt, який розбирається (RFC 9309: щонайменше 500 KiB)
+const MaxSize = 500 * 1024
+
+// Access визначає, як robots.txt впливає на доступ до сторінок хоста
+type Access string
+
+const (
+	AccessRules       Access = "rules"        // Діють правила з robots.txt
+	AccessAllowAll    Access = "allow_all"    // robots.txt недоступний (4xx) — дозволено все
+	AccessDisallowAll Access = "disallow_all" // Помилка сервера (5xx) або мережі — заборонено все
+)
+
+// Типи зауважень до robots.txt
+const (
+	IssueSyntaxError        = "syntax_error"
+	IssueUnknownDirective   = "unknown_directive"
+	IssueRuleOutsideGroup   = "rule_outside_group"
+	IssueInvalidWildcard    = "invalid_wildcard"
+	IssueRedundantWildcard  = "redundant_wildcard"
+	IssueInvalidPattern     = "invalid_pattern"
+	IssueInvalidSitemapURL  = "invalid_sitemap_url"
+	IssueUnreachableSitemap = "unreachable_sitemap"
+	IssueSizeLimit          = "size_limit"
+)
+
+// knownDirectives — директиви, які не вважаються невідомими
+var knownDirectives = map[string]bool{
+	"user-agent":  true,
+	"allow":       true,
+	"disallow":    true,
+	"sitemap":     true,
+	"crawl-delay": true,
+	"host":        true,
+	"clean-param": true,
+}
+
+// Rule — правило Allow або Disallow з robots.txt
+type Rule struct {
+	Allow   bool   `json:"allow"`   // true для Allow, false для Disallow
+	Pattern string `json:"pattern"` // Нормалізований шаблон шляху
+	Line    int    `json:"line"`    // Номер рядка в robots.txt
+}
+
+// Group — група правил для одного або кількох user-agent
+type Group struct {
+	UserAgents []string // Значення рядків user-agent у нижньому регістрі
+	Rules      []Rule
+	Line       int // Номер рядка першого user-agent групи
+}
+
+// Sitemap — директива Sitemap з robots.txt
+type Sitemap struct {
+	URL  string `json:"url"`
+	Line int    `json:"line"`
+}
+
+// Issue — зауваження до вмісту robots.txt
+type Issue struct {
+	Line    int    `json:"line"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// Robots — розібраний robots.txt
+type Robots struct {
+	Access   Access
+	Groups   []Group
+	Sitemaps []Sitemap
+	Issues   []Issue
+}
+
+// Result — результат перевірки URL за правилами robots.txt
+type Result struct {
+	Allowed bool  `json:"allowed"`
+	Rule    *Rule `json:"rule,omitempty"` // Правило, що визначило результат; nil — жодне не застосовано
+}
+
+// URLFor повертає адресу robots.txt для хоста сторінки
+func URLFor(pageURL string) (string, error) {
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return "", fmt.Errorf("помилка при парсингу URL: %v", err)
+	}
+	return fmt.Sprintf("%s://%s/robots.txt", u.Scheme, u.Host), nil
+}
+
+// FromResponse трактує відповідь сервера згідно з RFC 9309: 2xx — правила з тіла,
+// 3xx після вичерпання редіректів та 4xx — дозволено все, 5xx — заборонено все
+func FromResponse(statusCode int, body []byte) *Robots {
+	switch {
+	case statusCode >= 200 && statusCode < 300:
+		return Parse(body)
+	case statusCode >= 300 && statusCode < 500:
+		return &Robots{Access: AccessAllowAll}
+	default:
+		return Unreachable()
+	}
+}
+
+// Unreachable повертає robots.txt для хоста, з яким не вдалося з'єднатися
+func Unreachable() *Robots {
+	return &Robots{Access: AccessDisallowAll}
+}
+
+// Parse розбирає robots.txt згідно з RFC 9309 і збирає зауваження до його вмісту
+func Parse(data []byte) *Robots {
+	r := &Robots{Access: AccessRules}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")) // UTF-8 BOM
+
+	// Розбираємо лише перші MaxSize байт, відкидаючи обрізаний рядок
+	if len(data) > MaxSize {
+		r.addIssue(0, IssueSizeLimit, fmt.Sprintf("розмір %d байт перевищує ліміт %d, решта файлу ігнорується", len(data), MaxSize))
+		data = data[:MaxSize]
+		if i := bytes.LastIndexByte(data, '\n'); i >= 0 {
+			data = data[:i]
+		}
+	}
+
+	var current *Group
+	inRules := false // Чи були в поточній групі правила (новий user-agent після них починає нову групу)
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), len(data)+1)
+	line := 0
+	for scanner.Scan() {
+		line++
+		key, value, ok := splitLine(scanner.Text())
+		if !ok {
+			if strings.TrimSpace(stripComment(scanner.Text())) != "" {
+				r.addIssue(line, IssueSyntaxError, "рядок не містить двокрапки")
+			}
+			continue
+		}
+		if !knownDirectives[key] {
+			r.addIssue(line, IssueUnknownDirective, fmt.Sprintf("невідома директива %q", key))
+		}
+
+		switch key {
+		case "user-agent":
+			if current == nil || inRules {
+				r.Groups = append(r.Groups, Group{Line: line})
+				current = &r.Groups[len(r.Groups)-1]
+				inRules = false
+			}
+			current.UserAgents = append(current.UserAgents, strings.ToLower(value))
+		case "allow", "disallow":
+			if current == nil {
+				// Правило поза групою ігнорується
+				r.addIssue(line, IssueRuleOutsideGroup, fmt.Sprintf("%s до першого user-agent", key))
+				continue
+			}
+			inRules = true
+			if value == "" {
+				continue // Порожній шаблон не збігається з жодним шляхом
+			}
+			r.lintPattern(line, value)
+			current.Rules = append(current.Rules, Rule{
+				Allow:   key == "allow",
+				Pattern: normalize(value),
+				Line:    line,
+			})
+		case "sitemap":
+			if u, err := url.Parse(value); err != nil || !u.IsAbs() {
+				r.addIssue(line, IssueInvalidSitemapURL, fmt.Sprintf("URL sitemap має бути абсолютним: %q", value))
+			}
+			r.Sitemaps = append(r.Sitemaps, Sitemap{URL: value, Line: line})
+		default:
+			// Інші записи (crawl-delay тощо) не розривають групу
+			if current != nil {
+				inRules = true
+			}
+		}
+	}
+
+	return r
+}
+
+// Test перевіряє, чи дозволено userAgent завантажувати pageURL
+func (r *Robots) Test(userAgent, pageURL string) Result {
+	path := "/"
+	if u, err := url.Parse(pageURL); err == nil {
+		path = u.EscapedPath()
+		if path == "" {
+			path = "/"
+		}
+		if u.RawQuery != "" {
+			path += "?" + u.RawQuery
+		}
+	}
+
+	// robots.txt завжди дозволений
+	if path == "/robots.txt" {
+		return Result{Allowed: true}
+	}
+
+	switch r.Access {
+	case AccessAllowAll:
+		return Result{Allowed: true}
+	case AccessDisallowAll:
+		return Result{Allowed: false}
+	}
+
+	return match(r.rulesFor(ProductToken(userAgent)), normalize(path))
+}
+
+// rulesFor обирає групи з найточнішим збігом за токеном продукту серед усіх
+// груп (RFC 9309, розділ 2.2.1): групи з таким самим токеном об'єднуються,
+// а групи "*" діють, лише якщо жодна група не називає токен явно
+func (r *Robots) rulesFor(token string) []Rule {
+	var specific, wildcard []Rule
+	found := false
+	for _, group := range r.Groups {
+		named, wild := false, false
+		for _, agent := range group.UserAgents {
+			switch {
+			case agent == "*":
+				wild = true
+			case ProductToken(agent) == token:
+				named = true
+			}
+		}
+		// Група може одночасно називати токен і "*": тоді вона враховується як явна
+		if named {
+			specific = append(specific, group.Rules...)
+			found = true
+		} else if wild {
+			wildcard = append(wildcard, group.Rules...)
+		}
+	}
+
+	if found {
+		return specific
+	}
+	return wildcard
+}
+
+// ProductToken повертає токен продукту з рядка User-Agent у нижньому регістрі
+// (наприклад, "Googlebot/2.1 (+http://...)" → "googlebot")
+func ProductToken(userAgent string) string {
+	token := strings.TrimSpace(userAgent)
+	if i := strings.IndexAny(token, "/ "); i >= 0 {
+		token = token[:i]
+	}
+	return strings.ToLower(token)
+}
+
+// match обирає найдовше правило, що збігається зі шляхом; при однаковій
+// довжині перевага надається Allow
+func match(rules []Rule, path string) Result {
+	var best *Rule
+	for i := range rules {
+		rule := &rules[i]
+		if !matches(rule.Pattern, path) {
+			continue
+		}
+		if best == nil ||
+			len(rule.Pattern) > len(best.Pattern) ||
+			(len(rule.Pattern) == len(best.Pattern) && rule.Allow && !best.Allow) {
+			best = rule
+		}
+	}
+
+	if best == nil {
+		return Result{Allowed: true}
+	}
+	return Result{Allowed: best.Allow, Rule: best}
+}
+
+// matches перевіряє шлях на відповідність шаблону з "*" та "$" в кінці
+func matches(pattern, path string) bool {
+	anchored := strings.HasSuffix(pattern, "$")
+	pattern = strings.TrimSuffix(pattern, "$")
+
+	parts := strings.Split(pattern, "*")
+	if !strings.HasPrefix(path, parts[0]) {
+		return false
+	}
+	pos := len(parts[0])
+
+	for i := 1; i < len(parts); i++ {
+		if i == len(parts)-1 && anchored {
+			return strings.HasSuffix(path[pos:], parts[i])
+		}
+		idx := strings.Index(path[pos:], parts[i])
+		if idx < 0 {
+			return false
+		}
+		pos += idx + len(parts[i])
+	}
+
+	return !anchored || pos == len(path)
+}
+
+// normalize приводить percent-encoding до єдиного вигляду: незарезервовані
+// символи декодуються, решта кодується з великими шістнадцятковими цифрами,
+// не-ASCII байти кодуються
+func normalize(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '%' && i+2 < len(s) && isHex(s[i+1]) && isHex(s[i+2]):
+			decoded := unhex(s[i+1])<<4 | unhex(s[i+2])
+			if isUnreserved(decoded) {
+				b.WriteByte(decoded)
+			} else {
+				b.WriteByte('%')
+				b.WriteString(strings.ToUpper(s[i+1 : i+3]))
+			}
+			i += 2
+		case c >= 0x80 || c <= 0x20:
+			b.WriteString("%" + hexByte(c))
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String()
+}
+
+// lintPattern перевіряє коректність шаблону шляху
+func (r *Robots) lintPattern(line int, pattern string) {
+	if !strings.HasPrefix(pattern, "/") && !strings.HasPrefix(pattern, "*") {
+		r.addIssue(line, IssueInvalidPattern, fmt.Sprintf("шаблон має починатися з '/' або '*': %q", pattern))
+	}
+	if i := strings.IndexByte(pattern, '$'); i >= 0 && i != len(pattern)-1 {
+		r.addIssue(line, IssueInvalidWildcard, fmt.Sprintf("'$' допустимий лише в кінці шаблону: %q", pattern))
+	}
+	if strings.Contains(pattern, "**") || (len(pattern) > 1 && strings.HasSuffix(pattern, "*")) {
+		r.addIssue(line, IssueRedundantWildcard, fmt.Sprintf("зайвий '*' у шаблоні: %q", pattern))
+	}
+}
+
+// addIssue додає зауваження до robots.txt
+func (r *Robots) addIssue(line int, issueType, message string) {
+	r.Issues = append(r.Issues, Issue{Line: line, Type: issueType, Message: message})
+}
+
+// stripComment відкидає коментар з рядка
+func stripComment(line string) string {
+	if i := strings.IndexByte(line, '#'); i >= 0 {
+		return line[:i]
+	}
+	return line
+}
+
+// splitLine розбирає рядок "ключ: значення # коментар"
+func splitLine(line string) (string, string, bool) {
+	key, value, ok := strings.Cut(stripComment(line), ":")
+	if !ok {
+		return "", "", false
+	}
+	return strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value), true
+}
+
+func isUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isHex(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+func unhex(c byte) byte {
+	switch {
+	case '0' <= c && c <= '9':
+		return c - '0'
+	case 'a' <= c && c <= 'f':
+		return c - 'a' + 10
+	default:
+		return c - 'A' + 10
+	}
+}
+
+func hexByte(c byte) string {
+	const digits = "0123456789ABCDEF"
+	return string([]byte{digits[c>>4], digits[c&0x0f]})
+}
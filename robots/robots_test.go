@@ -0,0 +1,162 @@
+package robots
+
+import "testing"
+
+func TestMatches(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"/", "/any", true},
+		{"/fish", "/fish.html", true},
+		{"/fish", "/Fish", false},
+		{"/fish/", "/fish", false},
+		{"/*.php", "/index.php", true},
+		{"/*.php", "/dir/index.php?x=1", true},
+		{"/*.php", "/", false},
+		{"/*.php$", "/index.php", true},
+		{"/*.php$", "/index.php?x=1", false},
+		{"/fish*.php", "/fish/salmon.php", true},
+		{"/fish*.php", "/fishheads/catfish.php?x", true},
+		{"/fish*.php", "/Fish.PHP", false},
+		{"/$", "/", true},
+		{"/$", "/a", false},
+		{"*/b", "/a/b", true},
+		{"/a*b*c$", "/a-b-c", true},
+		{"/a*b*c$", "/a-b-c-", false},
+		{"/a*c$", "/abcbc", true},
+	}
+
+	for _, tt := range tests {
+		if got := matches(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("matches(%q, %q) = %v, очікувалось %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestMatchLongestRule(t *testing.T) {
+	tests := []struct {
+		name      string
+		robots    string
+		path      string
+		allowed   bool
+		ruleLine  int // 0 — жодне правило не застосовано
+		ruleAllow bool
+	}{
+		{"без правил", "User-agent: *\n", "/a", true, 0, false},
+		{"найдовший Disallow", "User-agent: *\nAllow: /\nDisallow: /private/\n", "/private/x", false, 3, false},
+		{"найдовший Allow", "User-agent: *\nDisallow: /private/\nAllow: /private/public/\n", "/private/public/x", true, 3, true},
+		{"рівна довжина — Allow", "User-agent: *\nDisallow: /page\nAllow: /page\n", "/page", true, 3, true},
+		{"рівна довжина — Allow незалежно від порядку", "User-agent: *\nAllow: /page\nDisallow: /page\n", "/page", true, 2, true},
+		{"рівна довжина з шаблонами", "User-agent: *\nDisallow: /*.gif\nAllow: /a.gif\n", "/a.gif", true, 3, true},
+		{"$ обмежує збіг", "User-agent: *\nDisallow: /*.pdf$\n", "/doc.pdf?download=1", true, 0, false},
+		{"percent-encoding", "User-agent: *\nDisallow: /%7Euser/\n", "/~user/page", false, 2, false},
+		{"robots.txt завжди дозволений", "User-agent: *\nDisallow: /\n", "/robots.txt", true, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Parse([]byte(tt.robots)).Test("sitemap-checker/1.0", "https://example.com"+tt.path)
+			if result.Allowed != tt.allowed {
+				t.Errorf("Allowed = %v, очікувалось %v", result.Allowed, tt.allowed)
+			}
+			switch {
+			case tt.ruleLine == 0 && result.Rule != nil:
+				t.Errorf("застосовано правило %+v, очікувалось жодного", *result.Rule)
+			case tt.ruleLine != 0 && (result.Rule == nil || result.Rule.Line != tt.ruleLine || result.Rule.Allow != tt.ruleAllow):
+				t.Errorf("правило = %+v, очікувалось рядок %d (allow %v)", result.Rule, tt.ruleLine, tt.ruleAllow)
+			}
+		})
+	}
+}
+
+func TestGroupSelection(t *testing.T) {
+	tests := []struct {
+		name      string
+		robots    string
+		userAgent string
+		path      string
+		allowed   bool
+	}{
+		{
+			"власна група замість *",
+			"User-agent: *\nDisallow: /\n\nUser-agent: sitemap-checker\nDisallow: /private/\n",
+			"sitemap-checker/1.0", "/page", true,
+		},
+		{
+			"* перша в групі з токеном",
+			"User-agent: *\nUser-agent: sitemap-checker\nDisallow: /private/\n\nUser-agent: *\nDisallow: /\n",
+			"sitemap-checker/1.0", "/page", true,
+		},
+		{
+			"* перша в групі з токеном — правила групи діють",
+			"User-agent: *\nUser-agent: sitemap-checker\nDisallow: /private/\n\nUser-agent: *\nDisallow: /\n",
+			"sitemap-checker/1.0", "/private/x", false,
+		},
+		{
+			"група * без власної групи",
+			"User-agent: googlebot\nDisallow: /\n\nUser-agent: *\nDisallow: /private/\n",
+			"sitemap-checker/1.0", "/page", true,
+		},
+		{
+			"токен без урахування регістру та версії",
+			"User-agent: Sitemap-Checker/2.0\nDisallow: /\n",
+			"sitemap-checker/1.0", "/page", false,
+		},
+		{
+			"інший токен з тим самим префіксом",
+			"User-agent: sitemap-checker-news\nDisallow: /\n",
+			"sitemap-checker/1.0", "/page", true,
+		},
+		{
+			"порожня власна група дозволяє все",
+			"User-agent: sitemap-checker\nCrawl-delay: 1\n\nUser-agent: *\nDisallow: /\n",
+			"sitemap-checker/1.0", "/page", true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse([]byte(tt.robots)).Test(tt.userAgent, "https://example.com"+tt.path).Allowed; got != tt.allowed {
+				t.Errorf("Allowed = %v, очікувалось %v", got, tt.allowed)
+			}
+		})
+	}
+}
+
+func TestGroupMerging(t *testing.T) {
+	r := Parse([]byte(`User-agent: sitemap-checker
+Disallow: /a/
+
+User-agent: *
+Disallow: /
+
+User-agent: Sitemap-Checker
+Disallow: /b/
+Allow: /a/public/
+`))
+
+	tests := []struct {
+		path    string
+		allowed bool
+	}{
+		{"/a/x", false},
+		{"/b/x", false},
+		{"/a/public/x", true},
+		{"/c/x", true},
+	}
+	for _, tt := range tests {
+		if got := r.Test("sitemap-checker/1.0", "https://example.com"+tt.path).Allowed; got != tt.allowed {
+			t.Errorf("%s: Allowed = %v, очікувалось %v", tt.path, got, tt.allowed)
+		}
+	}
+
+	// Групи * також об'єднуються
+	r = Parse([]byte("User-agent: *\nDisallow: /a/\n\nUser-agent: other\nDisallow: /\n\nUser-agent: *\nDisallow: /b/\n"))
+	for path, allowed := range map[string]bool{"/a/x": false, "/b/x": false, "/c/x": true} {
+		if got := r.Test("sitemap-checker/1.0", "https://example.com"+path).Allowed; got != allowed {
+			t.Errorf("*: %s: Allowed = %v, очікувалось %v", path, got, allowed)
+		}
+	}
+}
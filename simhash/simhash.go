@@ -0,0 +1,105 @@
+// Package simhash обчислює 64-бітні відбитки SimHash тексту для пошуку
+// майже однакових сторінок
+package simhash
+
+import (
+	"hash/fnv"
+	"math"
+	"math/bits"
+	"strings"
+	"unicode"
+)
+
+// shingleSize — кількість слів у шинглі
+const shingleSize = 3
+
+// Words розбиває текст на слова в нижньому регістрі
+func Words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// Fingerprint обчислює SimHash за шинглами зі слів; для порожнього списку — 0
+func Fingerprint(words []string) uint64 {
+	var weights [64]int
+
+	add := func(shingle string) {
+		h := fnv.New64a()
+		h.Write([]byte(shingle))
+		sum := h.Sum64()
+		for i := 0; i < 64; i++ {
+			if sum&(1<<uint(i)) != 0 {
+				weights[i]++
+			} else {
+				weights[i]--
+			}
+		}
+	}
+
+	if len(words) < shingleSize {
+		if len(words) > 0 {
+			add(strings.Join(words, " "))
+		}
+	} else {
+		for i := 0; i+shingleSize <= len(words); i++ {
+			add(strings.Join(words[i:i+shingleSize], " "))
+		}
+	}
+
+	var fingerprint uint64
+	for i, w := range weights {
+		if w > 0 {
+			fingerprint |= 1 << uint(i)
+		}
+	}
+	return fingerprint
+}
+
+// Distance повертає відстань Хеммінга між відбитками
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// Similarity повертає схожість відбитків від 0 до 1
+func Similarity(a, b uint64) float64 {
+	return 1 - float64(Distance(a, b))/64
+}
+
+// Band — блок бітів відбитка з його номером
+type Band struct {
+	Index int
+	Value uint64
+}
+
+// Bands ділить відбиток на n блоків майже однакової довжини. Якщо відстань між
+// відбитками менша за n, за принципом Діріхле хоча б один блок у них однаковий,
+// тож кандидатів на схожість достатньо шукати серед відбитків зі спільним блоком
+func Bands(fingerprint uint64, n int) []Band {
+	if n < 1 {
+		n = 1
+	}
+	if n > 64 {
+		n = 64
+	}
+	bands := make([]Band, n)
+	start := 0
+	for i := 0; i < n; i++ {
+		width := (64 - start) / (n - i)
+		bands[i] = Band{Index: i, Value: fingerprint >> uint(start) & (1<<uint(width) - 1)}
+		start += width
+	}
+	return bands
+}
+
+// MaxDistance повертає найбільшу відстань Хеммінга, за якої схожість не менша за similarity
+func MaxDistance(similarity float64) int {
+	distance := int(math.Floor((1-similarity)*64 + 1e-9))
+	if distance < 0 {
+		return 0
+	}
+	if distance > 64 {
+		return 64
+	}
+	return distance
+}
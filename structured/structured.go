@@ -0,0 +1,247 @@
+// Package structured розбирає структуровані дані JSON-LD та перевіряє їх
+// за вбудованим набором правил schema.org для розширених результатів пошуку
+package structured
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Типи проблем у структурованих даних
+const (
+	IssueMissingType        = "missing_type"
+	IssueMissingContext     = "missing_context"
+	IssueMissingRequired    = "missing_required"
+	IssueMissingRecommended = "missing_recommended"
+)
+
+// Issue — проблема сутності структурованих даних
+type Issue struct {
+	Type     string `json:"type"`
+	Property string `json:"property,omitempty"` // Властивість; альтернативи розділені "|"
+	Message  string `json:"message"`
+}
+
+// Entity — сутність із @type, знайдена в блоці JSON-LD
+type Entity struct {
+	Types  []string `json:"types,omitempty"`
+	Path   string   `json:"path"`             // Шлях у блоці, наприклад $.offers[0]
+	Known  bool     `json:"known"`            // Для типу є правила перевірки
+	Issues []Issue  `json:"issues,omitempty"` // Результат перевірки за правилами
+
+	properties map[string]interface{}
+}
+
+// Rule — обов'язкові та рекомендовані властивості типу
+type Rule struct {
+	Extends     string   `json:"extends,omitempty"`     // Тип, правила якого успадковуються
+	Required    []string `json:"required,omitempty"`    // Альтернативи розділені "|"
+	Recommended []string `json:"recommended,omitempty"` // Альтернативи розділені "|"
+}
+
+//go:embed rules.json
+var rulesJSON []byte
+
+// rules — вбудований набір правил за типом schema.org
+var rules = mustLoadRules(rulesJSON)
+
+func mustLoadRules(data []byte) map[string]Rule {
+	var result map[string]Rule
+	if err := json.Unmarshal(data, &result); err != nil {
+		panic(fmt.Sprintf("некоректний набір правил structured/rules.json: %v", err))
+	}
+	return result
+}
+
+// SyntaxError — помилка синтаксису JSON у блоці
+type SyntaxError struct {
+	Line int // Рядок у блоці, починаючи з 1
+	Err  error
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("рядок %d: %v", e.Line, e.Err)
+}
+
+func (e *SyntaxError) Unwrap() error {
+	return e.Err
+}
+
+// Parse розбирає блок JSON-LD і повертає всі сутності з @type, включно з вкладеними,
+// перевірені за вбудованими правилами
+func Parse(data string) ([]Entity, error) {
+	var root interface{}
+	if err := json.Unmarshal([]byte(data), &root); err != nil {
+		line := 1
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) && syntaxErr.Offset <= int64(len(data)) {
+			line += strings.Count(data[:syntaxErr.Offset], "\n")
+		}
+		return nil, &SyntaxError{Line: line, Err: err}
+	}
+
+	var entities []Entity
+	collect(root, "$", true, nil, &entities)
+	for i := range entities {
+		entities[i].validate()
+	}
+	return entities, nil
+}
+
+// collect обходить значення JSON і збирає сутності; top — вузол верхнього рівня,
+// де очікуються @context та @type; context — успадкований @context
+func collect(value interface{}, path string, top bool, context interface{}, entities *[]Entity) {
+	switch v := value.(type) {
+	case []interface{}:
+		for i, item := range v {
+			collect(item, fmt.Sprintf("%s[%d]", path, i), top, context, entities)
+		}
+	case map[string]interface{}:
+		if own, ok := v["@context"]; ok {
+			context = own
+		}
+		if graph, ok := v["@graph"]; ok {
+			collect(graph, path+".@graph", true, context, entities)
+		}
+
+		types := typesOf(v["@type"])
+		if len(types) > 0 {
+			entity := Entity{Types: types, Path: path, properties: v}
+			if top && !hasSchemaContext(context) {
+				entity.Issues = append(entity.Issues, Issue{Type: IssueMissingContext, Message: "@context не вказує на schema.org"})
+			}
+			*entities = append(*entities, entity)
+		} else if top && v["@graph"] == nil {
+			*entities = append(*entities, Entity{Path: path, Issues: []Issue{{Type: IssueMissingType, Message: "сутність без @type"}}})
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			if !strings.HasPrefix(key, "@") {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			collect(v[key], path+"."+key, false, context, entities)
+		}
+	}
+}
+
+// typesOf повертає значення @type як список без префікса schema.org
+func typesOf(value interface{}) []string {
+	var result []string
+	switch v := value.(type) {
+	case string:
+		result = append(result, shortType(v))
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, shortType(s))
+			}
+		}
+	}
+	return result
+}
+
+func shortType(t string) string {
+	t = strings.TrimSpace(t)
+	for _, prefix := range []string{"https://schema.org/", "http://schema.org/", "schema:"} {
+		t = strings.TrimPrefix(t, prefix)
+	}
+	return t
+}
+
+// hasSchemaContext перевіряє, що @context посилається на schema.org
+func hasSchemaContext(value interface{}) bool {
+	switch v := value.(type) {
+	case string:
+		return strings.Contains(strings.ToLower(v), "schema.org")
+	case []interface{}:
+		for _, item := range v {
+			if hasSchemaContext(item) {
+				return true
+			}
+		}
+	case map[string]interface{}:
+		if vocab, ok := v["@vocab"]; ok {
+			return hasSchemaContext(vocab)
+		}
+	}
+	return false
+}
+
+// validate перевіряє властивості сутності за правилами її типів
+func (e *Entity) validate() {
+	for _, t := range e.Types {
+		rule, ok := ruleFor(t)
+		if !ok {
+			continue
+		}
+		e.Known = true
+
+		for _, prop := range rule.Required {
+			if !e.hasAny(prop) {
+				e.Issues = append(e.Issues, Issue{
+					Type:     IssueMissingRequired,
+					Property: prop,
+					Message:  fmt.Sprintf("%s: відсутня обов'язкова властивість %s", t, strings.ReplaceAll(prop, "|", " або ")),
+				})
+			}
+		}
+		for _, prop := range rule.Recommended {
+			if !e.hasAny(prop) {
+				e.Issues = append(e.Issues, Issue{
+					Type:     IssueMissingRecommended,
+					Property: prop,
+					Message:  fmt.Sprintf("%s: відсутня рекомендована властивість %s", t, strings.ReplaceAll(prop, "|", " або ")),
+				})
+			}
+		}
+	}
+}
+
+// ruleFor повертає правила типу з урахуванням успадкування
+func ruleFor(t string) (Rule, bool) {
+	rule, ok := rules[t]
+	for depth := 0; ok && rule.Extends != "" && depth < 5; depth++ {
+		parent, found := rules[rule.Extends]
+		if !found {
+			break
+		}
+		rule = Rule{
+			Extends:     parent.Extends,
+			Required:    append(append([]string(nil), parent.Required...), rule.Required...),
+			Recommended: append(append([]string(nil), parent.Recommended...), rule.Recommended...),
+		}
+	}
+	return rule, ok
+}
+
+// hasAny перевіряє наявність непорожньої властивості з альтернатив "a|b"
+func (e *Entity) hasAny(props string) bool {
+	for _, prop := range strings.Split(props, "|") {
+		if !isEmpty(e.properties[prop]) {
+			return true
+		}
+	}
+	return false
+}
+
+func isEmpty(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,118 @@
+package structured
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// entitySummary — шлях, типи та проблеми сутності для порівняння в тестах
+type entitySummary struct {
+	Path   string
+	Types  []string
+	Known  bool
+	Issues []string // Тип:властивість
+}
+
+func summarize(entities []Entity) []entitySummary {
+	var result []entitySummary
+	for _, e := range entities {
+		s := entitySummary{Path: e.Path, Types: e.Types, Known: e.Known}
+		for _, issue := range e.Issues {
+			s.Issues = append(s.Issues, issue.Type+":"+issue.Property)
+		}
+		result = append(result, s)
+	}
+	return result
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []entitySummary
+	}{
+		{
+			"повний продукт із вкладеною пропозицією",
+			`{"@context": "https://schema.org", "@type": "Product", "name": "Чайник", "image": "a.jpg", "description": "Опис",
+			  "sku": "1", "brand": {"@type": "Brand", "name": "X"}, "aggregateRating": {"@type": "AggregateRating", "ratingValue": 4, "reviewCount": 3, "bestRating": 5, "worstRating": 1},
+			  "review": [{"@type": "Review", "author": "Іван", "reviewRating": {"@type": "Rating", "ratingValue": 5, "bestRating": 5, "worstRating": 1}, "datePublished": "2026-01-01"}],
+			  "offers": {"@type": "Offer", "price": "100", "priceCurrency": "UAH", "availability": "InStock", "url": "/p", "priceValidUntil": "2027-01-01"}}`,
+			[]entitySummary{
+				{Path: "$", Types: []string{"Product"}, Known: true},
+				{Path: "$.aggregateRating", Types: []string{"AggregateRating"}, Known: true},
+				{Path: "$.brand", Types: []string{"Brand"}},
+				{Path: "$.offers", Types: []string{"Offer"}, Known: true},
+				{Path: "$.review[0]", Types: []string{"Review"}, Known: true},
+				{Path: "$.review[0].reviewRating", Types: []string{"Rating"}, Known: true},
+			},
+		},
+		{
+			"обов'язкові альтернативи та рекомендовані властивості",
+			`{"@context": "http://schema.org/", "@type": "schema:Product", "name": " ", "offers": []}`,
+			[]entitySummary{{Path: "$", Types: []string{"Product"}, Known: true, Issues: []string{
+				"missing_required:name", "missing_required:offers|review|aggregateRating",
+				"missing_recommended:image", "missing_recommended:description", "missing_recommended:sku", "missing_recommended:brand",
+				"missing_recommended:offers", "missing_recommended:aggregateRating", "missing_recommended:review",
+			}}},
+		},
+		{
+			"успадкування правил",
+			`{"@context": "https://schema.org", "@type": "NewsArticle", "headline": "Новина", "image": ["a.jpg"], "datePublished": "2026-10-18"}`,
+			[]entitySummary{{Path: "$", Types: []string{"NewsArticle"}, Known: true, Issues: []string{
+				"missing_recommended:dateModified", "missing_recommended:author", "missing_recommended:publisher",
+			}}},
+		},
+		{
+			"@graph успадковує @context",
+			`{"@context": {"@vocab": "https://schema.org/"}, "@graph": [{"@type": "Organization", "name": "X", "url": "/", "logo": "l.png", "sameAs": ["a"], "contactPoint": {"@type": "ContactPoint"}}, {"name": "без типу"}]}`,
+			[]entitySummary{
+				{Path: "$.@graph[0]", Types: []string{"Organization"}, Known: true},
+				{Path: "$.@graph[0].contactPoint", Types: []string{"ContactPoint"}},
+				{Path: "$.@graph[1]", Issues: []string{"missing_type:"}},
+			},
+		},
+		{
+			"без schema.org у @context",
+			`[{"@context": "https://example.com", "@type": "Thing"}, {"@type": ["Corporation", "https://schema.org/Thing"], "@context": "https://schema.org", "name": "X", "url": "/", "logo": "l", "sameAs": "s", "contactPoint": "c"}]`,
+			[]entitySummary{
+				{Path: "$[0]", Types: []string{"Thing"}, Issues: []string{"missing_context:"}},
+				{Path: "$[1]", Types: []string{"Corporation", "Thing"}, Known: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entities, err := Parse(tt.data)
+			if err != nil {
+				t.Fatalf("Parse: %v", err)
+			}
+			if got := summarize(entities); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse =\n%+v\nочікувалось\n%+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSyntaxError(t *testing.T) {
+	tests := []struct {
+		data string
+		line int
+	}{
+		{`{"@type": "Product",}`, 1},
+		{"{\n  \"@type\": \"Product\",\n  \"name\": \"X\"\n  \"sku\": \"1\"\n}", 4},
+		{"\n\n", 3},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.data)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Parse(%q) = %v, очікувалась SyntaxError", tt.data, err)
+			continue
+		}
+		if syntaxErr.Line != tt.line {
+			t.Errorf("Parse(%q): рядок %d, очікувався %d", tt.data, syntaxErr.Line, tt.line)
+		}
+	}
+}